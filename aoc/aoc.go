@@ -0,0 +1,49 @@
+package aoc
+
+import (
+	"io"
+	"strconv"
+)
+
+type (
+	// Answer is the result of one part of a puzzle
+	Answer interface {
+		String() string
+	}
+
+	// Int is a numeric answer
+	Int int
+
+	// Text is a textual answer, which may span several lines
+	Text string
+
+	// PartFunc solves one part of a puzzle from its input
+	PartFunc func(r io.Reader) (Answer, error)
+
+	// Solver solves both parts of a day's puzzle
+	Solver interface {
+		Part1(r io.Reader) (Answer, error)
+		Part2(r io.Reader) (Answer, error)
+	}
+
+	// Funcs is a Solver made of a function for each part
+	Funcs struct {
+		P1, P2 PartFunc
+	}
+)
+
+func (a Int) String() string {
+	return strconv.Itoa(int(a))
+}
+
+func (a Text) String() string {
+	return string(a)
+}
+
+func (f Funcs) Part1(r io.Reader) (Answer, error) {
+	return f.P1(r)
+}
+
+func (f Funcs) Part2(r io.Reader) (Answer, error) {
+	return f.P2(r)
+}
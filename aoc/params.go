@@ -0,0 +1,67 @@
+package aoc
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xorkevin/advent2019/parse"
+)
+
+var (
+	ErrParams = errors.New("invalid params")
+)
+
+type (
+	// Params are named puzzle parameters given apart from the input, each
+	// with the position of its value
+	Params map[string]parse.Field
+
+	// ParamSolver is a Solver whose puzzle takes parameters besides its
+	// input
+	ParamSolver interface {
+		Solver
+		WithParams(p Params) (Solver, error)
+	}
+)
+
+// ParseParams reads one name = value pair per line, ignoring blank lines and
+// lines starting with #
+func ParseParams(r io.Reader) (Params, error) {
+	lines, err := parse.NonBlank(r)
+	if err != nil {
+		return nil, err
+	}
+	p := Params{}
+	for _, line := range lines {
+		line = line.TrimSpace()
+		if line.Text[0] == '#' {
+			continue
+		}
+		k := strings.IndexByte(line.Text, '=')
+		if k < 0 {
+			return nil, line.EndErrorf("%w: missing =", ErrParams)
+		}
+		name := line.Sub(0, k).TrimSpace()
+		if len(name.Text) == 0 {
+			return nil, line.Errorf("%w: missing name", ErrParams)
+		}
+		p[name.Text] = line.Sub(k+1, len(line.Text)).TrimSpace()
+	}
+	return p, nil
+}
+
+// Int returns a numeric param, or def when it is not given. An invalid value
+// is located where the param was given.
+func (p Params) Int(name string, def int) (int, error) {
+	v, ok := p[name]
+	if !ok {
+		return def, nil
+	}
+	num, err := strconv.Atoi(v.Text)
+	if err != nil {
+		return 0, v.Errorf("%w: %s: %q is not an integer", ErrParams, name, v.Text)
+	}
+	return num, nil
+}
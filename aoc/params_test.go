@@ -0,0 +1,73 @@
+package aoc_test
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/internal/testutil"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func TestParseParams(t *testing.T) {
+	p, err := aoc.ParseParams(parse.Named("params.txt", strings.NewReader("# comment\n\ntarget = 19690720\nname=a = b\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"target": "19690720", "name": "a = b"}
+	values := map[string]string{}
+	for k, v := range p {
+		values[k] = v.Text
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected %v, got %v", expected, values)
+	}
+	for _, tc := range []struct {
+		name     string
+		def      int
+		expected int
+	}{
+		{name: "target", def: 0, expected: 19690720},
+		{name: "missing", def: 5, expected: 5},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := p.Int(tc.name, tc.def)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v != tc.expected {
+				t.Fatalf("expected %d, got %d", tc.expected, v)
+			}
+		})
+	}
+	_, err = p.Int("name", 0)
+	if !errors.Is(err, aoc.ErrParams) {
+		t.Fatalf("expected %v, got %v", aoc.ErrParams, err)
+	}
+	if expected := `params.txt:4:6: invalid params: name: "a = b" is not an integer`; err.Error() != expected {
+		t.Fatalf("expected %s, got %v", expected, err)
+	}
+}
+
+func TestParseParamsInvalid(t *testing.T) {
+	for _, tc := range []string{"target\n", " = 1\n"} {
+		t.Run(tc, func(t *testing.T) {
+			if _, err := aoc.ParseParams(strings.NewReader(tc)); !errors.Is(err, aoc.ErrParams) {
+				t.Fatalf("expected %v, got %v", aoc.ErrParams, err)
+			}
+		})
+	}
+}
+
+func TestFuzzParseParams(t *testing.T) {
+	tokens := []string{"target", "=", " = ", "19690720", "#", " ", "\n"}
+	if err := testutil.Fuzz(tokens, func(r io.Reader) error {
+		_, err := aoc.ParseParams(r)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,167 @@
+package aoc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrAnswers  = errors.New("invalid answers file")
+	ErrMismatch = errors.New("answer mismatch")
+	ErrNoAnswer = errors.New("no expected answer")
+)
+
+const (
+	answerHeader = "== part "
+)
+
+type (
+	// Answers are the expected answers of a day by part, stored in a file
+	// where each answer follows a header line naming its part:
+	//
+	//   == part 1
+	//   3287899
+	//   == part 2
+	//   4928963
+	//
+	// so that an answer may span several lines.
+	Answers map[int]string
+
+	// MismatchError is a computed answer that differs from the expected one
+	MismatchError struct {
+		Part int
+		Want string
+		Got  string
+	}
+)
+
+// normalize trims trailing whitespace from every line and trailing blank
+// lines, which are not significant in an answer
+func normalize(s string) string {
+	lines := strings.Split(s, "\n")
+	for n, i := range lines {
+		lines[n] = strings.TrimRight(i, " \t\r")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// ParseAnswers reads an answers file
+func ParseAnswers(r io.Reader) (Answers, error) {
+	answers := Answers{}
+	part := 0
+	lines := []string{}
+	flush := func() {
+		if part > 0 {
+			answers[part] = normalize(strings.Join(lines, "\n"))
+		}
+		lines = lines[:0]
+	}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, answerHeader) {
+			if part == 0 && len(strings.TrimSpace(line)) > 0 {
+				return nil, fmt.Errorf("%w: line %d: answer before a part header", ErrAnswers, n)
+			}
+			lines = append(lines, line)
+			continue
+		}
+		flush()
+		k, err := strconv.Atoi(strings.TrimSpace(line[len(answerHeader):]))
+		if err != nil || k < 1 {
+			return nil, fmt.Errorf("%w: line %d: invalid part %q", ErrAnswers, n, line)
+		}
+		if _, ok := answers[k]; ok {
+			return nil, fmt.Errorf("%w: line %d: duplicate part %d", ErrAnswers, n, k)
+		}
+		part = k
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return answers, nil
+}
+
+// Write writes the answers in the format read by ParseAnswers
+func (a Answers) Write(w io.Writer) error {
+	parts := make([]int, 0, len(a))
+	for k := range a {
+		parts = append(parts, k)
+	}
+	sort.Ints(parts)
+	b := bufio.NewWriter(w)
+	for _, i := range parts {
+		fmt.Fprintf(b, "%s%d\n%s\n", answerHeader, i, normalize(a[i]))
+	}
+	return b.Flush()
+}
+
+// Check compares a computed answer with the expected answer of a part
+func (a Answers) Check(part int, got Answer) error {
+	want, ok := a[part]
+	if !ok {
+		return fmt.Errorf("%w for part %d", ErrNoAnswer, part)
+	}
+	if g := normalize(got.String()); g != want {
+		return &MismatchError{
+			Part: part,
+			Want: want,
+			Got:  g,
+		}
+	}
+	return nil
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("part %d: %v:\n%s", e.Part, ErrMismatch, Diff(e.Want, e.Got))
+}
+
+func (e *MismatchError) Unwrap() error {
+	return ErrMismatch
+}
+
+// Diff returns a line diff from want to got, with removed lines marked - and
+// added lines marked +
+func Diff(want, got string) string {
+	a := strings.Split(want, "\n")
+	b := strings.Split(got, "\n")
+	// lcs[i][j] is the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	s := strings.Builder{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			s.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case j == len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			s.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			s.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+	return s.String()
+}
@@ -0,0 +1,103 @@
+package aoc
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const letters = `#..#.####
+#..#.#...
+####.###.
+#..#.#...
+#..#.#...
+#..#.####`
+
+func TestParseAnswers(t *testing.T) {
+	in := "== part 1\n42\n== part 2\n" + letters + "\n\n"
+	answers, err := ParseAnswers(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answers[1] != "42" {
+		t.Errorf("part 1 = %q", answers[1])
+	}
+	if answers[2] != letters {
+		t.Errorf("part 2 = %q", answers[2])
+	}
+
+	b := bytes.Buffer{}
+	if err := answers.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	again, err := ParseAnswers(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != 2 || again[1] != answers[1] || again[2] != answers[2] {
+		t.Errorf("round trip = %q", again)
+	}
+}
+
+func TestParseAnswersInvalid(t *testing.T) {
+	for _, i := range []string{
+		"42\n",
+		"== part one\n42\n",
+		"== part 0\n42\n",
+		"== part 1\n1\n== part 1\n2\n",
+	} {
+		if _, err := ParseAnswers(strings.NewReader(i)); !errors.Is(err, ErrAnswers) {
+			t.Errorf("%q: err = %v", i, err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	answers := Answers{
+		1: "42",
+		2: letters,
+	}
+	if err := answers.Check(1, Int(42)); err != nil {
+		t.Error(err)
+	}
+	if err := answers.Check(2, Text(letters+"  \n")); err != nil {
+		t.Errorf("trailing whitespace is significant: %v", err)
+	}
+	if err := answers.Check(3, Int(0)); !errors.Is(err, ErrNoAnswer) {
+		t.Errorf("missing part: %v", err)
+	}
+
+	wrong := strings.Replace(letters, "#..#.####\n#..#.#...\n####", "#..#.####\n#..#.#...\n#..#", 1)
+	err := answers.Check(2, Text(wrong))
+	if !errors.Is(err, ErrMismatch) {
+		t.Fatalf("mismatch: %v", err)
+	}
+	var m *MismatchError
+	if !errors.As(err, &m) || m.Part != 2 {
+		t.Fatalf("mismatch error = %v", err)
+	}
+	diff := Diff(m.Want, m.Got)
+	if !strings.Contains(diff, "- ####.###.\n+ #..#.###.\n") {
+		t.Errorf("diff does not show the changed line:\n%s", diff)
+	}
+	if n := strings.Count("\n"+diff, "\n- "); n != 1 {
+		t.Errorf("diff removes %d lines:\n%s", n, diff)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		want, got, diff string
+	}{
+		{"a\nb\nc", "a\nb\nc", "  a\n  b\n  c\n"},
+		{"a\nb\nc", "a\nc", "  a\n- b\n  c\n"},
+		{"a\nc", "a\nb\nc", "  a\n+ b\n  c\n"},
+		{"1", "2", "- 1\n+ 2\n"},
+	}
+	for _, i := range cases {
+		if d := Diff(i.want, i.got); d != i.diff {
+			t.Errorf("Diff(%q, %q) = %q, want %q", i.want, i.got, d, i.diff)
+		}
+	}
+}
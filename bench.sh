@@ -1,11 +1,7 @@
 #!/usr/bin/env bash
 
-basedir=$PWD
-days=$(find . -maxdepth 1 -type d -name 'day*' | sort)
+# runs the Go benchmarks for every day against the stored baseline, then
+# compares wall clock time with the Rust solutions; any other arguments are
+# passed to cmd/bench, e.g. -save to store a new baseline
 
-for i in $days
-do
-  echo "BENCHMARKING $i..."
-  cd "$basedir/$i"
-  make bench
-done
+go run ./cmd/bench -rust "$@"
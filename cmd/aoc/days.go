@@ -0,0 +1,54 @@
+package main
+
+import (
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day01"
+	"github.com/xorkevin/advent2019/day02"
+	"github.com/xorkevin/advent2019/day03"
+	"github.com/xorkevin/advent2019/day04"
+	"github.com/xorkevin/advent2019/day05"
+	"github.com/xorkevin/advent2019/day06"
+	"github.com/xorkevin/advent2019/day07"
+	"github.com/xorkevin/advent2019/day08"
+	"github.com/xorkevin/advent2019/day09"
+	"github.com/xorkevin/advent2019/day10"
+	"github.com/xorkevin/advent2019/day11"
+	"github.com/xorkevin/advent2019/day12"
+	"github.com/xorkevin/advent2019/day13"
+	"github.com/xorkevin/advent2019/day14"
+	"github.com/xorkevin/advent2019/day15"
+	"github.com/xorkevin/advent2019/day16"
+	"github.com/xorkevin/advent2019/day17"
+	"github.com/xorkevin/advent2019/day18"
+	"github.com/xorkevin/advent2019/day19"
+	"github.com/xorkevin/advent2019/day20"
+	"github.com/xorkevin/advent2019/day21"
+	"github.com/xorkevin/advent2019/day22"
+)
+
+var (
+	solvers = map[int]aoc.Solver{
+		1:  aoc.Funcs{P1: day01.Part1, P2: day01.Part2},
+		2:  day02.Solver{Target: day02.DefaultTarget},
+		3:  aoc.Funcs{P1: day03.Part1, P2: day03.Part2},
+		4:  aoc.Funcs{P1: day04.Part1, P2: day04.Part2},
+		5:  aoc.Funcs{P1: day05.Part1, P2: day05.Part2},
+		6:  aoc.Funcs{P1: day06.Part1, P2: day06.Part2},
+		7:  aoc.Funcs{P1: day07.Part1, P2: day07.Part2},
+		8:  aoc.Funcs{P1: day08.Part1, P2: day08.Part2},
+		9:  aoc.Funcs{P1: day09.Part1, P2: day09.Part2},
+		10: aoc.Funcs{P1: day10.Part1, P2: day10.Part2},
+		11: aoc.Funcs{P1: day11.Part1, P2: day11.Part2},
+		12: aoc.Funcs{P1: day12.Part1, P2: day12.Part2},
+		13: aoc.Funcs{P1: day13.Part1, P2: day13.Part2},
+		14: aoc.Funcs{P1: day14.Part1, P2: day14.Part2},
+		15: aoc.Funcs{P1: day15.Part1, P2: day15.Part2},
+		16: aoc.Funcs{P1: day16.Part1, P2: day16.Part2},
+		17: aoc.Funcs{P1: day17.Part1, P2: day17.Part2},
+		18: aoc.Funcs{P1: day18.Part1, P2: day18.Part2},
+		19: aoc.Funcs{P1: day19.Part1, P2: day19.Part2},
+		20: aoc.Funcs{P1: day20.Part1, P2: day20.Part2},
+		21: aoc.Funcs{P1: day21.Part1, P2: day21.Part2},
+		22: aoc.Funcs{P1: day22.Part1, P2: day22.Part2},
+	}
+)
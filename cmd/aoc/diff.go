@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/input"
+	"github.com/xorkevin/advent2019/internal/rust"
+)
+
+type (
+	// diffCase is one input on which the Go and Rust solutions are compared
+	diffCase struct {
+		Name  string
+		File  string
+		Input []byte
+	}
+
+	// diffResult is the outcome of comparing a day on one input, with Go and
+	// Rust holding the normalized output of each
+	diffResult struct {
+		Day  int
+		Case string
+		Go   string
+		Rust string
+		Err  error
+	}
+)
+
+// normalizers adjust the Rust output of a day to match the Go answers
+var normalizers = map[int]func(lines []string) []string{
+	// the Rust solution prints the result of every diagnostic test, which
+	// the Go solution checks are zero
+	5: func(lines []string) []string {
+		k := []string{}
+		for _, i := range lines {
+			if i != "0" {
+				k = append(k, i)
+			}
+		}
+		return k
+	},
+}
+
+// normalize trims trailing whitespace from every line and drops blank lines
+func normalize(s string) []string {
+	lines := []string{}
+	for _, i := range strings.Split(s, "\n") {
+		i = strings.TrimRight(i, " \t\r")
+		if i != "" {
+			lines = append(lines, i)
+		}
+	}
+	return lines
+}
+
+// runRust runs a Rust solution with the input as input.txt in a directory of
+// its own, since each reads its input from the working directory
+func runRust(bin string, dir string, input []byte) (string, error) {
+	if err := ioutil.WriteFile(filepath.Join(dir, "input.txt"), input, 0644); err != nil {
+		return "", err
+	}
+	cmd := exec.Command(bin)
+	cmd.Dir = dir
+	out := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s: %w\n%s", filepath.Base(bin), err, stderr.String())
+	}
+	return out.String(), nil
+}
+
+// runGo returns the answers of both parts, one line each
+func runGo(s aoc.Solver, day int, name string, input []byte) (string, error) {
+	lines := []string{}
+	for _, part := range []int{1, 2} {
+		r := run(s, day, part, name, input)
+		if r.Err != nil {
+			return "", fmt.Errorf("part %d: %w", part, r.Err)
+		}
+		lines = append(lines, normalize(r.Answer.String())...)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func diffCases(day int, name string, input []byte, seed int64, generated int) []diffCase {
+	cases := []diffCase{
+		{Name: "input", File: name, Input: input},
+	}
+	gen, ok := generators[day]
+	if !ok {
+		return cases
+	}
+	rng := rand.New(rand.NewSource(seed + int64(day)))
+	for i := 0; i < generated; i++ {
+		cases = append(cases, diffCase{
+			Name:  fmt.Sprintf("generated %d", i+1),
+			File:  fmt.Sprintf("generated%d.txt", i+1),
+			Input: gen(rng),
+		})
+	}
+	return cases
+}
+
+// diffDay compares the Go and Rust solutions of a day on each case
+func diffDay(s aoc.Solver, day int, bin string, dir string, cases []diffCase) []diffResult {
+	results := make([]diffResult, 0, len(cases))
+	for _, c := range cases {
+		r := diffResult{
+			Day:  day,
+			Case: c.Name,
+		}
+		r.Go, r.Err = runGo(s, day, c.File, c.Input)
+		if r.Err == nil {
+			var out string
+			out, r.Err = runRust(bin, dir, c.Input)
+			lines := normalize(out)
+			if n, ok := normalizers[day]; ok {
+				lines = n(lines)
+			}
+			r.Rust = strings.Join(lines, "\n")
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func (r diffResult) Diverged() bool {
+	return r.Err == nil && r.Go != r.Rust
+}
+
+func (r diffResult) write(w io.Writer) {
+	label := fmt.Sprintf("day %02d %s", r.Day, r.Case)
+	if r.Err != nil {
+		fmt.Fprintf(w, "%s: error: %v\n", label, r.Err)
+		return
+	}
+	if r.Diverged() {
+		fmt.Fprintf(w, "%s: DIVERGED\n%s", label, aoc.Diff(r.Go, r.Rust))
+		return
+	}
+	fmt.Fprintf(w, "%s: ok\n", label)
+}
+
+// diffRust compares the Go and Rust solutions of the days which have both,
+// on their input and on generated inputs, returning the days which diverge
+// or fail. Days whose Rust solution is missing, unimplemented or cannot be
+// built offline are skipped.
+func diffRust(w io.Writer, src input.Source, days []int, inputFile string, seed int64, generated int) ([]int, error) {
+	if err := rust.LookCargo(); err != nil {
+		return nil, err
+	}
+	tmp, err := ioutil.TempDir("", "advent2019-diff")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	target := filepath.Join(tmp, "target")
+	failed := []int{}
+	for _, day := range days {
+		if err := rust.Check(dayDir(day)); err != nil {
+			fmt.Fprintf(w, "day %02d: skipped: %v\n", day, err)
+			continue
+		}
+		bin, err := rust.Build(dayDir(day), target)
+		if err != nil {
+			fmt.Fprintf(w, "day %02d: skipped: %v\n", day, err)
+			continue
+		}
+		name, in, err := readInput(src, day, inputFile)
+		if err != nil {
+			return nil, err
+		}
+		s, err := src.Solver(day, solvers[day])
+		if err != nil {
+			return nil, err
+		}
+		dir := filepath.Join(tmp, dayDir(day))
+		if err := os.Mkdir(dir, 0755); err != nil {
+			return nil, err
+		}
+		ok := true
+		for _, r := range diffDay(s, day, bin, dir, diffCases(day, name, in, seed, generated)) {
+			r.write(w)
+			if r.Err != nil || r.Diverged() {
+				ok = false
+			}
+		}
+		if !ok {
+			failed = append(failed, day)
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestGenerators checks that every generated input is deterministic and
+// solved by the Go solution
+func TestGenerators(t *testing.T) {
+	for day := 1; day <= len(solvers); day++ {
+		gen, ok := generators[day]
+		if !ok {
+			continue
+		}
+		t.Run(dayDir(day), func(t *testing.T) {
+			a := gen(rand.New(rand.NewSource(1)))
+			b := gen(rand.New(rand.NewSource(1)))
+			if !bytes.Equal(a, b) {
+				t.Fatal("generated inputs differ for the same seed")
+			}
+			if _, err := runGo(solvers[day], day, "generated.txt", a); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	for _, tc := range []struct {
+		day      int
+		out      string
+		expected []string
+	}{
+		{day: 1, out: "12  \r\n\n34\n", expected: []string{"12", "34"}},
+		{day: 5, out: "0\n0\n0\n9025675\n11981754\n", expected: []string{"9025675", "11981754"}},
+	} {
+		t.Run(dayDir(tc.day), func(t *testing.T) {
+			lines := normalize(tc.out)
+			if n, ok := normalizers[tc.day]; ok {
+				lines = n(lines)
+			}
+			if !reflect.DeepEqual(lines, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, lines)
+			}
+		})
+	}
+}
+
+func TestDiffResult(t *testing.T) {
+	r := diffResult{Day: 1, Case: "input", Go: "1\n2", Rust: "1\n3"}
+	if !r.Diverged() {
+		t.Fatal("expected divergence")
+	}
+	b := strings.Builder{}
+	r.write(&b)
+	if expected := "day 01 input: DIVERGED\n  1\n- 2\n+ 3\n"; b.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, b.String())
+	}
+}
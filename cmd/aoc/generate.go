@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+type (
+	// generator produces a random valid input for a day
+	generator func(rng *rand.Rand) []byte
+)
+
+// generators are the days with random inputs for the differential test
+var generators = map[int]generator{
+	1:  genMasses,
+	3:  genWires,
+	6:  genOrbits,
+	10: genAsteroids,
+}
+
+func genMasses(rng *rand.Rand) []byte {
+	b := bytes.Buffer{}
+	for i := 0; i < 100; i++ {
+		fmt.Fprintln(&b, 50000+rng.Intn(100000))
+	}
+	return b.Bytes()
+}
+
+func genWire(rng *rand.Rand, start string) string {
+	segments := []string{start}
+	for i := 0; i < 50; i++ {
+		segments = append(segments, fmt.Sprintf("%c%d", "URDL"[rng.Intn(4)], 1+rng.Intn(100)))
+	}
+	return strings.Join(segments, ",")
+}
+
+// genWires starts the wires so that they always cross at 5,5
+func genWires(rng *rand.Rand) []byte {
+	return []byte(genWire(rng, "U5,R10") + "\n" + genWire(rng, "R5,U10") + "\n")
+}
+
+func genName(rng *rand.Rand, used map[string]bool) string {
+	for {
+		b := []byte{'A' + byte(rng.Intn(26)), 'A' + byte(rng.Intn(26)), '0' + byte(rng.Intn(10))}
+		if name := string(b); !used[name] {
+			used[name] = true
+			return name
+		}
+	}
+}
+
+// genOrbits generates a random tree rooted at COM, with YOU and SAN each
+// orbiting some body, listed in a random order
+func genOrbits(rng *rand.Rand) []byte {
+	used := map[string]bool{}
+	bodies := []string{"COM"}
+	lines := []string{}
+	for i := 0; i < 300; i++ {
+		name := genName(rng, used)
+		lines = append(lines, bodies[rng.Intn(len(bodies))]+")"+name)
+		bodies = append(bodies, name)
+	}
+	for _, i := range []string{"YOU", "SAN"} {
+		lines = append(lines, bodies[1+rng.Intn(len(bodies)-1)]+")"+i)
+	}
+	rng.Shuffle(len(lines), func(i, j int) {
+		lines[i], lines[j] = lines[j], lines[i]
+	})
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
+
+// genAsteroids generates a field dense enough for the 200th asteroid to be
+// vaporized
+func genAsteroids(rng *rand.Rand) []byte {
+	b := bytes.Buffer{}
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 30; x++ {
+			if rng.Intn(2) == 0 {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
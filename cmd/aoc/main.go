@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/input"
+	"github.com/xorkevin/advent2019/internal/rust"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+type (
+	result struct {
+		Day    int
+		Part   int
+		Answer aoc.Answer
+		Err    error
+		Time   time.Duration
+		// Checked is set when the answer was checked, with any mismatch in
+		// Check
+		Checked bool
+		Check   error
+	}
+)
+
+func dayDir(day int) string {
+	return input.DayDir(day)
+}
+
+// readInput reads the whole input for a day, from stdin when path is -, so
+// that each part may read it again, along with its name for errors
+func readInput(src input.Source, day int, path string) (string, []byte, error) {
+	if path == "-" {
+		b, err := ioutil.ReadAll(os.Stdin)
+		return "stdin", b, err
+	}
+	if path == "" {
+		b, err := src.Read(day)
+		return src.Layout.Input(day), b, err
+	}
+	b, err := ioutil.ReadFile(path)
+	return path, b, err
+}
+
+func answersPath(l input.Layout, day int, path string) string {
+	if path == "" {
+		return l.Answers(day)
+	}
+	return path
+}
+
+func readAnswers(path string) (aoc.Answers, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.ParseAnswers(parse.Named(path, bytes.NewReader(b)))
+}
+
+// recordAnswers merges answers into those already stored, so that recording
+// one part keeps the other
+func recordAnswers(path string, results []result) error {
+	answers, err := readAnswers(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		answers = aoc.Answers{}
+	}
+	for _, i := range results {
+		if i.Err == nil {
+			answers[i.Part] = i.Answer.String()
+		}
+	}
+	b := bytes.Buffer{}
+	if err := answers.Write(&b); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b.Bytes(), 0644)
+}
+
+func run(s aoc.Solver, day, part int, name string, input []byte) result {
+	solve := s.Part1
+	if part == 2 {
+		solve = s.Part2
+	}
+	start := time.Now()
+	answer, err := solve(parse.Named(name, bytes.NewReader(input)))
+	return result{
+		Day:    day,
+		Part:   part,
+		Answer: answer,
+		Err:    err,
+		Time:   time.Since(start),
+	}
+}
+
+func (r result) write(w io.Writer) {
+	label := fmt.Sprintf("day %02d part %d", r.Day, r.Part)
+	if r.Err != nil {
+		fmt.Fprintf(w, "%s: error: %v\n", label, r.Err)
+		return
+	}
+	if r.Check != nil {
+		var m *aoc.MismatchError
+		if errors.As(r.Check, &m) {
+			fmt.Fprintf(w, "%s: FAIL (%s)\n%s", label, r.Time, aoc.Diff(m.Want, m.Got))
+		} else {
+			fmt.Fprintf(w, "%s: FAIL: %v\n", label, r.Check)
+		}
+		return
+	}
+	status := ""
+	if r.Checked {
+		status = " ok"
+	}
+	answer := strings.TrimRight(r.Answer.String(), "\n")
+	if strings.Contains(answer, "\n") {
+		fmt.Fprintf(w, "%s (%s)%s:\n%s\n", label, r.Time, status, answer)
+		return
+	}
+	fmt.Fprintf(w, "%s: %s (%s)%s\n", label, answer, r.Time, status)
+}
+
+func main() {
+	day := flag.Int("day", 0, "day to solve, or 0 for every day")
+	part := flag.Int("part", 0, "part to solve, or 0 for both")
+	inputFile := flag.String("input", "", "input file, or - for stdin (default dayNN/input.txt)")
+	verify := flag.Bool("verify", false, "check answers against the expected answers")
+	record := flag.Bool("record", false, "store answers as the expected answers")
+	answers := flag.String("answers", "", "expected answers file (default dayNN/answers.txt)")
+	diff := flag.Bool("diff", false, "compare answers with the Rust solutions on the input and generated inputs")
+	seed := flag.Int64("seed", 1, "random seed for generated inputs with -diff")
+	generated := flag.Int("generated", 3, "number of generated inputs per day with -diff")
+	user := flag.String("user", os.Getenv("AOC_USER"), "read inputs, params and answers from inputs/USER/dayNN instead of dayNN (default $AOC_USER)")
+	root := flag.String("inputs", "inputs", "directory of the inputs of each user")
+	fetch := flag.Bool("fetch", false, "download missing inputs using the session cookie in $AOC_SESSION")
+	flag.Parse()
+
+	src := input.Source{}
+	if *user != "" {
+		src.Layout = input.Layout{
+			Root: *root,
+			User: *user,
+		}
+	}
+	if *fetch {
+		session := os.Getenv("AOC_SESSION")
+		if session == "" {
+			log.Fatal("-fetch requires a session cookie in $AOC_SESSION")
+		}
+		src.Fetcher = input.NewFetcher(session)
+	}
+
+	if *part < 0 || *part > 2 {
+		log.Fatalf("invalid part %d", *part)
+	}
+	days := []int{}
+	if *day == 0 {
+		if *inputFile != "" || *answers != "" {
+			log.Fatal("an input or answers file may only be given with a day")
+		}
+		for k := range solvers {
+			days = append(days, k)
+		}
+		sort.Ints(days)
+	} else {
+		if _, ok := solvers[*day]; !ok {
+			log.Fatalf("no solver for day %d", *day)
+		}
+		days = append(days, *day)
+	}
+	if *diff {
+		failed, err := diffRust(os.Stdout, src, days, *inputFile, *seed, *generated)
+		if errors.Is(err, rust.ErrNoCargo) {
+			log.Println("cargo not found, skipping the Rust comparison")
+			return
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(failed) > 0 {
+			names := make([]string, 0, len(failed))
+			for _, i := range failed {
+				names = append(names, dayDir(i))
+			}
+			fmt.Printf("diverged: %s\n", strings.Join(names, ", "))
+			os.Exit(1)
+		}
+		return
+	}
+
+	parts := []int{1, 2}
+	if *part != 0 {
+		parts = []int{*part}
+	}
+
+	failed := false
+	for _, d := range days {
+		name, in, err := readInput(src, d, *inputFile)
+		if err != nil {
+			log.Println(err)
+			failed = true
+			continue
+		}
+		s, err := src.Solver(d, solvers[d])
+		if err != nil {
+			log.Println(err)
+			failed = true
+			continue
+		}
+		var expected aoc.Answers
+		if *verify {
+			expected, err = readAnswers(answersPath(src.Layout, d, *answers))
+			if err != nil {
+				log.Println(err)
+				failed = true
+				continue
+			}
+		}
+		results := make([]result, 0, len(parts))
+		for _, p := range parts {
+			r := run(s, d, p, name, in)
+			if r.Err == nil && expected != nil {
+				r.Checked = true
+				r.Check = expected.Check(p, r.Answer)
+			}
+			r.write(os.Stdout)
+			if r.Err != nil || r.Check != nil {
+				failed = true
+			}
+			results = append(results, r)
+		}
+		if *record {
+			if err := recordAnswers(answersPath(src.Layout, d, *answers), results); err != nil {
+				log.Println(err)
+				failed = true
+			}
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestAnswers checks every day against its stored expected answers
+func TestAnswers(t *testing.T) {
+	for day := 1; day <= len(solvers); day++ {
+		s, ok := solvers[day]
+		if !ok {
+			continue
+		}
+		dir := filepath.Join("..", "..", dayDir(day))
+		t.Run(dayDir(day), func(t *testing.T) {
+			input, err := ioutil.ReadFile(filepath.Join(dir, "input.txt"))
+			if os.IsNotExist(err) {
+				t.Skip("no input")
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected, err := readAnswers(filepath.Join(dir, "answers.txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, part := range []int{1, 2} {
+				t.Run(fmt.Sprintf("part%d", part), func(t *testing.T) {
+					r := run(s, day, part, filepath.Join(dir, "input.txt"), input)
+					if r.Err != nil {
+						t.Fatal(r.Err)
+					}
+					if err := expected.Check(part, r.Answer); err != nil {
+						t.Error(err)
+					}
+				})
+			}
+		})
+	}
+}
+
+// BenchmarkAnswers benchmarks each part of every day on its input, without
+// process startup or reading the input file
+func BenchmarkAnswers(b *testing.B) {
+	for day := 1; day <= len(solvers); day++ {
+		s, ok := solvers[day]
+		if !ok {
+			continue
+		}
+		b.Run(dayDir(day), func(b *testing.B) {
+			input, err := ioutil.ReadFile(filepath.Join("..", "..", dayDir(day), "input.txt"))
+			if os.IsNotExist(err) {
+				b.Skip("no input")
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+			for _, part := range []int{1, 2} {
+				solve := s.Part1
+				if part == 2 {
+					solve = s.Part2
+				}
+				b.Run(fmt.Sprintf("part%d", part), func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						if _, err := solve(bytes.NewReader(input)); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xorkevin/advent2019/input"
+	"github.com/xorkevin/advent2019/internal/rust"
+)
+
+// runBenchmarks runs the benchmarks of the aoc runner, echoing the output to
+// stderr as it runs
+func runBenchmarks(pattern, benchtime string) ([]Result, error) {
+	args := []string{"test", "-run", "^$", "-bench", pattern, "-benchmem"}
+	if benchtime != "" {
+		args = append(args, "-benchtime", benchtime)
+	}
+	args = append(args, "./cmd/aoc")
+	out := bytes.Buffer{}
+	cmd := exec.Command("go", args...)
+	cmd.Stdout = io.MultiWriter(&out, os.Stderr)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go test: %w", err)
+	}
+	return ParseResults(&out)
+}
+
+func readResults(path string) ([]Result, error) {
+	if path == "-" {
+		return ParseResults(os.Stdin)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Println(err)
+		}
+	}()
+	return ParseResults(file)
+}
+
+func readBaseline(path string) (Baseline, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Println(err)
+		}
+	}()
+	return ReadBaseline(file)
+}
+
+// saveBaseline merges results into the stored baseline, so that saving a
+// subset of the benchmarks keeps the others
+func saveBaseline(path string, results []Result) error {
+	base, err := readBaseline(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		base = Baseline{}
+	}
+	for _, i := range results {
+		base[i.Name] = i
+	}
+	b := bytes.Buffer{}
+	if err := base.Write(&b); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b.Bytes(), 0644)
+}
+
+// allDays lists the days with a directory
+func allDays() ([]int, error) {
+	dirs, err := filepath.Glob("day[0-9][0-9]")
+	if err != nil {
+		return nil, err
+	}
+	days := []int{}
+	for _, i := range dirs {
+		day, err := strconv.Atoi(strings.TrimPrefix(i, "day"))
+		if err != nil {
+			continue
+		}
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days, nil
+}
+
+func main() {
+	pattern := flag.String("bench", ".", "benchmarks to run, as for go test -bench")
+	benchtime := flag.String("benchtime", "", "time or iterations per benchmark, as for go test -benchtime")
+	inputFile := flag.String("input", "", "read go test -bench output from a file, or - for stdin, instead of running the benchmarks")
+	baseline := flag.String("baseline", "bench.json", "baseline results file")
+	save := flag.Bool("save", false, "store the results in the baseline file")
+	threshold := flag.Float64("threshold", 0.1, "fraction above the baseline that is flagged as a regression")
+	compare := flag.Bool("rust", false, "compare wall clock time of the Go and Rust binaries for days with a Rust solution")
+	day := flag.Int("day", 0, "day to benchmark, or 0 for every day")
+	warmup := flag.Int("warmup", 3, "unmeasured runs of each binary before timing with -rust")
+	runs := flag.Int("runs", 10, "timed runs of each binary with -rust")
+	flag.Parse()
+
+	if *day != 0 && *pattern == "." {
+		*pattern = fmt.Sprintf("Answers/%s/", input.DayDir(*day))
+	}
+
+	var results []Result
+	var err error
+	if *inputFile != "" {
+		results, err = readResults(*inputFile)
+	} else {
+		results, err = runBenchmarks(*pattern, *benchtime)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	base, err := readBaseline(*baseline)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Fatal(err)
+		}
+		base = Baseline{}
+	}
+	comparisons := base.Compare(results, *threshold)
+	if err := WriteTable(os.Stdout, comparisons); err != nil {
+		log.Fatal(err)
+	}
+	regressed := 0
+	for _, i := range comparisons {
+		if i.Regression {
+			regressed++
+		}
+	}
+
+	if *save {
+		if err := saveBaseline(*baseline, results); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("saved %d results to %s\n", len(results), *baseline)
+	}
+
+	if *compare {
+		days := []int{*day}
+		if *day == 0 {
+			days, err = allDays()
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		fmt.Println()
+		wall, err := CompareRust(os.Stdout, days, *warmup, *runs)
+		if errors.Is(err, ErrNoGo) || errors.Is(err, rust.ErrNoCargo) {
+			log.Printf("%v, skipping the Rust comparison", err)
+		} else if err != nil {
+			log.Fatal(err)
+		} else if err := WriteWallclock(os.Stdout, wall); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if regressed > 0 && !*save {
+		fmt.Printf("%d regressions beyond %.0f%%\n", regressed, *threshold*100)
+		os.Exit(1)
+	}
+}
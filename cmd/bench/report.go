@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+var (
+	ErrBenchLine = errors.New("invalid benchmark line")
+)
+
+type (
+	// Result is a single benchmark measurement
+	Result struct {
+		Name        string  `json:"name"`
+		Iterations  int     `json:"iterations"`
+		NsPerOp     float64 `json:"ns_per_op"`
+		BytesPerOp  int64   `json:"bytes_per_op"`
+		AllocsPerOp int64   `json:"allocs_per_op"`
+	}
+
+	// Baseline is a set of stored results, keyed by benchmark name
+	Baseline map[string]Result
+
+	// Comparison is a result checked against its baseline
+	Comparison struct {
+		Result
+		Base       *Result
+		Regression bool
+	}
+)
+
+// trimProcs removes the GOMAXPROCS suffix that go test adds to benchmark
+// names, so that baselines compare across machines
+func trimProcs(name string) string {
+	k := strings.LastIndexByte(name, '-')
+	if k < 0 {
+		return name
+	}
+	if _, err := strconv.Atoi(name[k+1:]); err != nil {
+		return name
+	}
+	return name[:k]
+}
+
+func parseLine(line string) (Result, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || len(fields)%2 != 0 {
+		return Result{}, fmt.Errorf("%w: %s", ErrBenchLine, line)
+	}
+	iters, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %s", ErrBenchLine, err)
+	}
+	r := Result{
+		Name:       strings.TrimPrefix(trimProcs(fields[0]), "Benchmark"),
+		Iterations: iters,
+	}
+	for i := 2; i < len(fields); i += 2 {
+		val, unit := fields[i], fields[i+1]
+		switch unit {
+		case "ns/op":
+			r.NsPerOp, err = strconv.ParseFloat(val, 64)
+		case "B/op":
+			r.BytesPerOp, err = strconv.ParseInt(val, 10, 64)
+		case "allocs/op":
+			r.AllocsPerOp, err = strconv.ParseInt(val, 10, 64)
+		}
+		if err != nil {
+			return Result{}, fmt.Errorf("%w: %s", ErrBenchLine, err)
+		}
+	}
+	return r, nil
+}
+
+// ParseResults reads the results from go test -bench output, ignoring every
+// other line
+func ParseResults(r io.Reader) ([]Result, error) {
+	results := []Result{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "Benchmark") || !strings.Contains(line, "ns/op") {
+			continue
+		}
+		res, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ReadBaseline reads a baseline stored as a json list of results
+func ReadBaseline(r io.Reader) (Baseline, error) {
+	results := []Result{}
+	if err := json.NewDecoder(r).Decode(&results); err != nil {
+		return nil, err
+	}
+	b := Baseline{}
+	for _, i := range results {
+		b[i.Name] = i
+	}
+	return b, nil
+}
+
+// Write stores the baseline as a json list of results sorted by name
+func (b Baseline) Write(w io.Writer) error {
+	results := make([]Result, 0, len(b))
+	for _, i := range b {
+		results = append(results, i)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+// exceeds reports whether v is more than threshold, a fraction, above base
+func exceeds(v, base, threshold float64) bool {
+	return v > base*(1+threshold)
+}
+
+// Compare checks each result against the baseline, marking a regression
+// when its time or allocations grow by more than threshold
+func (b Baseline) Compare(results []Result, threshold float64) []Comparison {
+	c := make([]Comparison, 0, len(results))
+	for _, i := range results {
+		k := Comparison{
+			Result: i,
+		}
+		if base, ok := b[i.Name]; ok {
+			k.Base = &base
+			k.Regression = exceeds(i.NsPerOp, base.NsPerOp, threshold) ||
+				exceeds(float64(i.AllocsPerOp), float64(base.AllocsPerOp), threshold)
+		}
+		c = append(c, k)
+	}
+	return c
+}
+
+func change(v, base float64) string {
+	if base == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", (v-base)/base*100)
+}
+
+// WriteTable writes the comparisons as an aligned table
+func WriteTable(w io.Writer, comparisons []Comparison) error {
+	t := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(t, "benchmark\tns/op\tB/op\tallocs/op\tdelta ns\tdelta allocs\t\t")
+	for _, i := range comparisons {
+		dns, dallocs := "", ""
+		if i.Base != nil {
+			dns = change(i.NsPerOp, i.Base.NsPerOp)
+			dallocs = change(float64(i.AllocsPerOp), float64(i.Base.AllocsPerOp))
+		}
+		status := ""
+		if i.Regression {
+			status = "REGRESSION"
+		}
+		fmt.Fprintf(t, "%s\t%.0f\t%d\t%d\t%s\t%s\t%s\t\n", i.Name, i.NsPerOp, i.BytesPerOp, i.AllocsPerOp, dns, dallocs, status)
+	}
+	return t.Flush()
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	benchOutput = `goos: linux
+goarch: amd64
+pkg: github.com/xorkevin/advent2019/cmd/aoc
+BenchmarkAnswers/day01/part1-8   	   40608	     29442 ns/op	   74272 B/op	     214 allocs/op
+BenchmarkAnswers/day01/part2     	   38649	     26271.5 ns/op
+PASS
+ok  	github.com/xorkevin/advent2019/cmd/aoc	5.157s
+`
+)
+
+func TestParseResults(t *testing.T) {
+	results, err := ParseResults(strings.NewReader(benchOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Result{
+		{Name: "Answers/day01/part1", Iterations: 40608, NsPerOp: 29442, BytesPerOp: 74272, AllocsPerOp: 214},
+		{Name: "Answers/day01/part2", Iterations: 38649, NsPerOp: 26271.5},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %v, got %v", expected, results)
+	}
+
+	if _, err := ParseResults(strings.NewReader("BenchmarkA 10 x ns/op\n")); !errors.Is(err, ErrBenchLine) {
+		t.Fatalf("expected %v, got %v", ErrBenchLine, err)
+	}
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	base := Baseline{
+		"b": {Name: "b", Iterations: 1, NsPerOp: 2, BytesPerOp: 3, AllocsPerOp: 4},
+		"a": {Name: "a", Iterations: 5, NsPerOp: 6.5},
+	}
+	b := bytes.Buffer{}
+	if err := base.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Index(b.String(), `"a"`) > strings.Index(b.String(), `"b"`) {
+		t.Errorf("results not sorted by name:\n%s", b.String())
+	}
+	read, err := ReadBaseline(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, base) {
+		t.Fatalf("expected %v, got %v", base, read)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	base := Baseline{
+		"same":   {Name: "same", NsPerOp: 100, AllocsPerOp: 10},
+		"slower": {Name: "slower", NsPerOp: 100, AllocsPerOp: 10},
+		"allocs": {Name: "allocs", NsPerOp: 100, AllocsPerOp: 10},
+		"faster": {Name: "faster", NsPerOp: 100, AllocsPerOp: 10},
+	}
+	for _, tc := range []struct {
+		result     Result
+		regression bool
+		hasBase    bool
+	}{
+		{result: Result{Name: "same", NsPerOp: 109, AllocsPerOp: 10}, hasBase: true},
+		{result: Result{Name: "slower", NsPerOp: 111, AllocsPerOp: 10}, regression: true, hasBase: true},
+		{result: Result{Name: "allocs", NsPerOp: 100, AllocsPerOp: 12}, regression: true, hasBase: true},
+		{result: Result{Name: "faster", NsPerOp: 50, AllocsPerOp: 5}, hasBase: true},
+		{result: Result{Name: "new", NsPerOp: 1000, AllocsPerOp: 1000}},
+	} {
+		t.Run(tc.result.Name, func(t *testing.T) {
+			c := base.Compare([]Result{tc.result}, 0.1)[0]
+			if c.Regression != tc.regression {
+				t.Errorf("expected regression %v, got %v", tc.regression, c.Regression)
+			}
+			if (c.Base != nil) != tc.hasBase {
+				t.Errorf("expected base %v, got %v", tc.hasBase, c.Base)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/xorkevin/advent2019/input"
+	"github.com/xorkevin/advent2019/internal/rust"
+)
+
+var (
+	ErrNoGo = errors.New("go not found")
+)
+
+type (
+	// Timing is the wall clock time of a whole binary over several runs
+	Timing struct {
+		Mean time.Duration
+		Min  time.Duration
+	}
+
+	// Wallclock compares the Go and Rust binaries for a day
+	Wallclock struct {
+		Day  int
+		Go   Timing
+		Rust Timing
+	}
+)
+
+// timeCmd runs a command warmup times unmeasured, then runs times measured
+func timeCmd(dir string, warmup, runs int, name string, args ...string) (Timing, error) {
+	t := Timing{}
+	var total time.Duration
+	for i := 0; i < warmup+runs; i++ {
+		cmd := exec.Command(name, args...)
+		cmd.Dir = dir
+		cmd.Stdout = ioutil.Discard
+		cmd.Stderr = os.Stderr
+		start := time.Now()
+		if err := cmd.Run(); err != nil {
+			return Timing{}, fmt.Errorf("%s: %w", name, err)
+		}
+		d := time.Since(start)
+		if i < warmup {
+			continue
+		}
+		total += d
+		if t.Min == 0 || d < t.Min {
+			t.Min = d
+		}
+	}
+	if runs > 0 {
+		t.Mean = total / time.Duration(runs)
+	}
+	return t, nil
+}
+
+// CompareRust builds the Go runner and every Rust solution into a temporary
+// directory, then times both on each day's input. Days whose Rust solution
+// is missing, unimplemented or cannot be built offline are skipped.
+func CompareRust(w io.Writer, days []int, warmup, runs int) ([]Wallclock, error) {
+	if _, err := exec.LookPath("go"); err != nil {
+		return nil, ErrNoGo
+	}
+	if err := rust.LookCargo(); err != nil {
+		return nil, err
+	}
+	tmp, err := ioutil.TempDir("", "advent2019-bench")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	goBin, err := filepath.Abs(filepath.Join(tmp, "aoc"))
+	if err != nil {
+		return nil, err
+	}
+	build := exec.Command("go", "build", "-o", goBin, "./cmd/aoc")
+	build.Stderr = os.Stderr
+	if err := build.Run(); err != nil {
+		return nil, fmt.Errorf("go build: %w", err)
+	}
+	target := filepath.Join(tmp, "target")
+	results := []Wallclock{}
+	for _, day := range days {
+		dir := input.DayDir(day)
+		if err := rust.Check(dir); err != nil {
+			fmt.Fprintf(w, "day %02d: skipped: %v\n", day, err)
+			continue
+		}
+		rsBin, err := rust.Build(dir, target)
+		if err != nil {
+			fmt.Fprintf(w, "day %02d: skipped: %v\n", day, err)
+			continue
+		}
+		goTime, err := timeCmd(dir, warmup, runs, goBin, "-day", strconv.Itoa(day), "-input", "input.txt")
+		if err != nil {
+			return nil, err
+		}
+		rsTime, err := timeCmd(dir, warmup, runs, rsBin)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, Wallclock{
+			Day:  day,
+			Go:   goTime,
+			Rust: rsTime,
+		})
+	}
+	return results, nil
+}
+
+// WriteWallclock writes the Go and Rust timings as an aligned table
+func WriteWallclock(w io.Writer, results []Wallclock) error {
+	t := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(t, "day\tgo mean\tgo min\trust mean\trust min\tgo/rust\t")
+	for _, i := range results {
+		ratio := "-"
+		if i.Rust.Mean > 0 {
+			ratio = fmt.Sprintf("%.2fx", float64(i.Go.Mean)/float64(i.Rust.Mean))
+		}
+		fmt.Fprintf(t, "%s\t%s\t%s\t%s\t%s\t%s\t\n", input.DayDir(i.Day), i.Go.Mean, i.Go.Min, i.Rust.Mean, i.Rust.Min, ratio)
+	}
+	return t.Flush()
+}
@@ -0,0 +1,36 @@
+package day01
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	nums, err := parse.Ints(r)
+	if err != nil {
+		return nil, err
+	}
+	k := 0
+	for _, num := range nums {
+		k += num/3 - 2
+	}
+	return aoc.Int(k), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	nums, err := parse.Ints(r)
+	if err != nil {
+		return nil, err
+	}
+	k := 0
+	for _, num := range nums {
+		j := num/3 - 2
+		for j > 0 {
+			k += j
+			j = j/3 - 2
+		}
+	}
+	return aoc.Int(k), nil
+}
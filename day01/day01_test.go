@@ -0,0 +1,30 @@
+package day01
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+)
+
+func TestFuel(t *testing.T) {
+	for _, tc := range []struct {
+		mass  string
+		part1 aoc.Answer
+		part2 aoc.Answer
+	}{
+		{mass: "12", part1: aoc.Int(2), part2: aoc.Int(2)},
+		{mass: "14", part1: aoc.Int(2), part2: aoc.Int(2)},
+		{mass: "1969", part1: aoc.Int(654), part2: aoc.Int(966)},
+		{mass: "100756", part1: aoc.Int(33583), part2: aoc.Int(50346)},
+	} {
+		t.Run(tc.mass, func(t *testing.T) {
+			if a, err := Part1(strings.NewReader(tc.mass)); err != nil || a != tc.part1 {
+				t.Errorf("part 1: expected %v, got %v (%v)", tc.part1, a, err)
+			}
+			if a, err := Part2(strings.NewReader(tc.mass)); err != nil || a != tc.part2 {
+				t.Errorf("part 2: expected %v, got %v (%v)", tc.part2, a, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package day02
+
+import (
+	"errors"
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/intcode"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+const (
+	// DefaultTarget is the output sought in part 2, unless set by the target
+	// param
+	DefaultTarget = 19690720
+)
+
+var (
+	ErrNoInputs = errors.New("no noun and verb produce the target")
+)
+
+type (
+	// Solver solves the puzzle for a target output
+	Solver struct {
+		Target int
+	}
+)
+
+func run(tokens []int, noun, verb int) (int, error) {
+	mem := make([]int, len(tokens))
+	copy(mem, tokens)
+	m := intcode.NewMachine(mem)
+	if err := m.MemSet(1, noun); err != nil {
+		return 0, err
+	}
+	if err := m.MemSet(2, verb); err != nil {
+		return 0, err
+	}
+	if err := m.Execute(); err != nil {
+		return 0, err
+	}
+	return m.MemAt(0)
+}
+
+func (s Solver) Part1(r io.Reader) (aoc.Answer, error) {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return nil, err
+	}
+	out, err := run(tokens, 12, 2)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(out), nil
+}
+
+// Part2 finds the noun and verb which produce the target output
+func (s Solver) Part2(r io.Reader) (aoc.Answer, error) {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < 100; i++ {
+		for j := 0; j < 100; j++ {
+			out, err := run(tokens, i, j)
+			if err != nil {
+				return nil, err
+			}
+			if out == s.Target {
+				return aoc.Int(i*100 + j), nil
+			}
+		}
+	}
+	return nil, ErrNoInputs
+}
+
+// WithParams sets the target from the target param
+func (s Solver) WithParams(p aoc.Params) (aoc.Solver, error) {
+	target, err := p.Int("target", s.Target)
+	if err != nil {
+		return nil, err
+	}
+	return Solver{
+		Target: target,
+	}, nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	return Solver{Target: DefaultTarget}.Part1(r)
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	return Solver{Target: DefaultTarget}.Part2(r)
+}
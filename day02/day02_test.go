@@ -0,0 +1,51 @@
+package day02
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/xorkevin/advent2019/intcode"
+)
+
+func TestMachine(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		mem      []int
+		expected []int
+	}{
+		{name: "example", mem: []int{1, 9, 10, 3, 2, 3, 11, 0, 99, 30, 40, 50}, expected: []int{3500, 9, 10, 70, 2, 3, 11, 0, 99, 30, 40, 50}},
+		{name: "add", mem: []int{1, 0, 0, 0, 99}, expected: []int{2, 0, 0, 0, 99}},
+		{name: "mul", mem: []int{2, 3, 0, 3, 99}, expected: []int{2, 3, 0, 6, 99}},
+		{name: "mul past halt", mem: []int{2, 4, 4, 5, 99, 0}, expected: []int{2, 4, 4, 5, 99, 9801}},
+		{name: "self modifying", mem: []int{1, 1, 1, 4, 99, 5, 6, 0, 99}, expected: []int{30, 1, 1, 4, 2, 5, 6, 0, 99}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := intcode.NewMachine(tc.mem)
+			if err := m.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tc.mem, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, tc.mem)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		mem      []int
+		expected error
+	}{
+		{name: "empty", mem: nil, expected: intcode.ErrMemBounds},
+		{name: "illegal op", mem: []int{42, 0, 0, 0, 99}, expected: intcode.ErrIllegalOp},
+		{name: "noun out of bounds", mem: []int{1, 0, 0, 0, 99}, expected: intcode.ErrMemBounds},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := run(tc.mem, 12, 2); !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
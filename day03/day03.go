@@ -0,0 +1,144 @@
+package day03
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+var (
+	ErrNoCross = errors.New("wires do not cross")
+	ErrStep    = errors.New("invalid wire step")
+)
+
+type (
+	Tuple struct {
+		x, y int
+	}
+
+	// Step moves a wire Len tiles in the direction U, D, R or L
+	Step struct {
+		Dir byte
+		Len int
+	}
+)
+
+func Abs(a, b int) int {
+	k := a - b
+	if k < 0 {
+		return -k
+	}
+	return k
+}
+
+func Dist(t Tuple, t2 Tuple) int {
+	return Abs(t.x, t2.x) + Abs(t.y, t2.y)
+}
+
+// parseWires reads one wire per line as its comma separated steps, such as
+// R8,U5
+func parseWires(r io.Reader) ([][]Step, error) {
+	lines, err := parse.NonBlank(r)
+	if err != nil {
+		return nil, err
+	}
+	wires := make([][]Step, 0, len(lines))
+	for _, line := range lines {
+		fields := line.Split(",")
+		wire := make([]Step, 0, len(fields))
+		for _, i := range fields {
+			i = i.TrimSpace()
+			if len(i.Text) < 2 || !strings.ContainsRune("UDRL", rune(i.Text[0])) {
+				return nil, i.Errorf("%w: %q", ErrStep, i.Text)
+			}
+			num, err := i.Sub(1, len(i.Text)).Int()
+			if err != nil {
+				return nil, err
+			}
+			if num < 0 {
+				return nil, i.Errorf("%w: %q is negative", ErrStep, i.Text)
+			}
+			wire = append(wire, Step{
+				Dir: i.Text[0],
+				Len: num,
+			})
+		}
+		wires = append(wires, wire)
+	}
+	return wires, nil
+}
+
+// cross returns the distance to the closest intersection of the first two
+// wires, and the fewest combined steps to reach an intersection
+func cross(r io.Reader) (int, int, error) {
+	wires, err := parseWires(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	wire1 := map[Tuple]int{}
+
+	dist := -1
+	dist2 := -1
+
+	first := true
+	for _, wire := range wires {
+		x := 0
+		y := 0
+		n := 0
+		for _, step := range wire {
+			for i := 0; i < step.Len; i++ {
+				switch step.Dir {
+				case 'U':
+					y -= 1
+				case 'D':
+					y += 1
+				case 'R':
+					x += 1
+				case 'L':
+					x -= 1
+				}
+				n++
+				if first {
+					wire1[Tuple{x, y}] = n
+				} else {
+					if w1, ok := wire1[Tuple{x, y}]; ok {
+						if k := Dist(Tuple{x, y}, Tuple{0, 0}); dist < 0 || k < dist {
+							dist = k
+						}
+						if dist2 < 0 || w1+n < dist2 {
+							dist2 = w1 + n
+						}
+					}
+				}
+			}
+		}
+		if first {
+			first = false
+		}
+	}
+
+	if dist < 0 {
+		return 0, 0, ErrNoCross
+	}
+	return dist, dist2, nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	dist, _, err := cross(r)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(dist), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	_, dist2, err := cross(r)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(dist2), nil
+}
@@ -0,0 +1,57 @@
+package day03
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func TestDist(t *testing.T) {
+	if d := Dist(Tuple{3, -3}, Tuple{0, 0}); d != 6 {
+		t.Fatalf("expected 6, got %d", d)
+	}
+}
+
+func TestCross(t *testing.T) {
+	for _, tc := range []struct {
+		file  string
+		dist  int
+		steps int
+	}{
+		{file: "example1.txt", dist: 6, steps: 30},
+		{file: "example2.txt", dist: 159, steps: 610},
+		{file: "example3.txt", dist: 135, steps: 410},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			dist, steps, err := cross(testutil.ReadFile(t, tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dist != tc.dist || steps != tc.steps {
+				t.Fatalf("expected %d and %d steps, got %d and %d steps", tc.dist, tc.steps, dist, steps)
+			}
+		})
+	}
+}
+
+func TestCrossInvalid(t *testing.T) {
+	if _, _, err := cross(strings.NewReader("R8,U5\nL5,D3\n")); !errors.Is(err, ErrNoCross) {
+		t.Errorf("parallel wires: %v", err)
+	}
+	if _, _, err := cross(strings.NewReader("R8,X5\nU7\n")); err == nil {
+		t.Error("invalid direction accepted")
+	}
+}
+
+func TestFuzzParseWires(t *testing.T) {
+	tokens := []string{"U", "D", "R", "L", "X", "0", "8", "75", "-", ",", " ", "\n"}
+	if err := testutil.Fuzz(tokens, func(r io.Reader) error {
+		_, err := parseWires(r)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
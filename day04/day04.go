@@ -0,0 +1,107 @@
+package day04
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func passToDigits(pass int) [6]int {
+	return [6]int{
+		pass / 100000 % 10,
+		pass / 10000 % 10,
+		pass / 1000 % 10,
+		pass / 100 % 10,
+		pass / 10 % 10,
+		pass % 10,
+	}
+}
+
+func isValidPass(pass int) bool {
+	s := passToDigits(pass)
+
+	hasDouble := false
+
+	k := s[0]
+	for _, i := range s[1:] {
+		if i == k {
+			hasDouble = true
+		}
+		if i < k {
+			return false
+		}
+		k = i
+	}
+
+	return hasDouble
+}
+
+func isValidPass2(pass int) bool {
+	if !isValidPass(pass) {
+		return false
+	}
+
+	s := passToDigits(pass)
+
+	hasRun2 := false
+
+	run := 1
+	k := s[0]
+	for _, i := range s[1:] {
+		if i == k {
+			run++
+		} else {
+			if run == 2 {
+				hasRun2 = true
+			}
+			run = 1
+		}
+		k = i
+	}
+
+	return hasRun2 || run == 2
+}
+
+// parseRange reads the puzzle input, a range of passwords written min-max
+func parseRange(r io.Reader) (int, int, error) {
+	line, err := parse.Line(r)
+	if err != nil {
+		return 0, 0, err
+	}
+	a, b, err := line.Cut("-")
+	if err != nil {
+		return 0, 0, err
+	}
+	min, err := a.Int()
+	if err != nil {
+		return 0, 0, err
+	}
+	max, err := b.Int()
+	if err != nil {
+		return 0, 0, err
+	}
+	return min, max, nil
+}
+
+func count(r io.Reader, valid func(pass int) bool) (aoc.Answer, error) {
+	min, max, err := parseRange(r)
+	if err != nil {
+		return nil, err
+	}
+	count := 0
+	for i := min; i <= max; i++ {
+		if valid(i) {
+			count++
+		}
+	}
+	return aoc.Int(count), nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	return count(r, isValidPass)
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	return count(r, isValidPass2)
+}
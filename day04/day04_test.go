@@ -0,0 +1,55 @@
+package day04
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func TestIsValidPass(t *testing.T) {
+	for _, tc := range []struct {
+		pass   int
+		valid  bool
+		valid2 bool
+	}{
+		{pass: 111111, valid: true, valid2: false},
+		{pass: 223450, valid: false, valid2: false},
+		{pass: 123789, valid: false, valid2: false},
+		{pass: 112233, valid: true, valid2: true},
+		{pass: 123444, valid: true, valid2: false},
+		{pass: 111122, valid: true, valid2: true},
+	} {
+		if v := isValidPass(tc.pass); v != tc.valid {
+			t.Errorf("isValidPass(%d): expected %t", tc.pass, tc.valid)
+		}
+		if v := isValidPass2(tc.pass); v != tc.valid2 {
+			t.Errorf("isValidPass2(%d): expected %t", tc.pass, tc.valid2)
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	a, err := Part1(strings.NewReader("111110-111123\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != aoc.Int(11) {
+		t.Fatalf("expected 11, got %v", a)
+	}
+	if _, err := Part1(strings.NewReader("111110\n")); err == nil {
+		t.Fatal("invalid range accepted")
+	}
+}
+
+func TestFuzzParseRange(t *testing.T) {
+	tokens := []string{"1", "231832", "-", " ", "\n", "x"}
+	if err := testutil.Fuzz(tokens, func(r io.Reader) error {
+		_, _, err := parseRange(r)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
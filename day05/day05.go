@@ -0,0 +1,48 @@
+package day05
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/intcode"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+var (
+	ErrNoOutput   = errors.New("program produced no output")
+	ErrDiagnostic = errors.New("diagnostic test failed")
+)
+
+// diagnose runs the program with a system id and returns its diagnostic
+// code, the last output, after checking every test before it passed
+func diagnose(r io.Reader, id int) (aoc.Answer, error) {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return nil, err
+	}
+	m := intcode.NewMachine(tokens)
+	m.SetInput(func() int { return id })
+	out, err := m.Outputs()
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, ErrNoOutput
+	}
+	for n, i := range out[:len(out)-1] {
+		if i != 0 {
+			return nil, fmt.Errorf("%w: test %d output %d", ErrDiagnostic, n, i)
+		}
+	}
+	return aoc.Int(out[len(out)-1]), nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	return diagnose(r, 1)
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	return diagnose(r, 5)
+}
@@ -0,0 +1,90 @@
+package day05
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/intcode"
+)
+
+func TestModes(t *testing.T) {
+	mem := []int{1002, 4, 3, 4, 33}
+	if err := intcode.NewMachine(mem).Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if mem[4] != 99 {
+		t.Fatalf("expected 99, got %d", mem[4])
+	}
+	mem = []int{1101, 100, -1, 4, 0}
+	if err := intcode.NewMachine(mem).Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if mem[4] != 99 {
+		t.Fatalf("expected 99, got %d", mem[4])
+	}
+}
+
+func TestCompare(t *testing.T) {
+	large := []int{3, 21, 1008, 21, 8, 20, 1005, 20, 22, 107, 8, 21, 20, 1006, 20, 31, 1106, 0, 36, 98, 0, 0, 1002, 21, 125, 20, 4, 20, 1105, 1, 46, 104, 999, 1105, 1, 46, 1101, 1000, 1, 20, 4, 20, 1105, 1, 46, 98, 99}
+	for _, tc := range []struct {
+		name     string
+		prog     []int
+		expected map[int]int
+	}{
+		{name: "position equal", prog: []int{3, 9, 8, 9, 10, 9, 4, 9, 99, -1, 8}, expected: map[int]int{7: 0, 8: 1, 9: 0}},
+		{name: "position less", prog: []int{3, 9, 7, 9, 10, 9, 4, 9, 99, -1, 8}, expected: map[int]int{7: 1, 8: 0, 9: 0}},
+		{name: "immediate equal", prog: []int{3, 3, 1108, -1, 8, 3, 4, 3, 99}, expected: map[int]int{7: 0, 8: 1, 9: 0}},
+		{name: "immediate less", prog: []int{3, 3, 1107, -1, 8, 3, 4, 3, 99}, expected: map[int]int{7: 1, 8: 0, 9: 0}},
+		{name: "position jump", prog: []int{3, 12, 6, 12, 15, 1, 13, 14, 13, 4, 13, 99, -1, 0, 1, 9}, expected: map[int]int{0: 0, 5: 1}},
+		{name: "immediate jump", prog: []int{3, 3, 1105, -1, 9, 1101, 0, 0, 12, 4, 12, 99, 1}, expected: map[int]int{0: 0, 5: 1}},
+		{name: "around eight", prog: large, expected: map[int]int{7: 999, 8: 1000, 9: 1001}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for inp, out := range tc.expected {
+				mem := make([]int, len(tc.prog))
+				copy(mem, tc.prog)
+				m := intcode.NewMachine(mem)
+				m.Write(inp)
+				outs, err := m.Outputs()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(outs, []int{out}) {
+					t.Errorf("input %d: expected [%d], got %v", inp, out, outs)
+				}
+			}
+		})
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	a, err := diagnose(strings.NewReader("104,0,104,0,104,42,99"), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != aoc.Int(42) {
+		t.Fatalf("expected 42, got %v", a)
+	}
+	if _, err := diagnose(strings.NewReader("104,0,104,3,104,42,99"), 1); !errors.Is(err, ErrDiagnostic) {
+		t.Fatalf("failed test not reported: %v", err)
+	}
+	if _, err := diagnose(strings.NewReader("99"), 1); !errors.Is(err, ErrNoOutput) {
+		t.Fatalf("missing output not reported: %v", err)
+	}
+	for _, tc := range []struct {
+		prog     string
+		expected error
+	}{
+		{prog: "104,0,42", expected: intcode.ErrIllegalOp},
+		{prog: "304,0,99", expected: intcode.ErrIllegalMode},
+		{prog: "1103,0,99", expected: intcode.ErrIllegalMode},
+		{prog: "4,9,99", expected: intcode.ErrMemBounds},
+	} {
+		if _, err := diagnose(strings.NewReader(tc.prog), 1); !errors.Is(err, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.prog, tc.expected, err)
+		}
+	}
+}
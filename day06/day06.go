@@ -0,0 +1,41 @@
+package day06
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day06/orbit"
+)
+
+const (
+	you   = "YOU"
+	santa = "SAN"
+)
+
+func parseOrbits(r io.Reader) (*orbit.Tree, error) {
+	orbits, err := orbit.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return orbit.NewTree(orbits, orbit.COM)
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	tree, err := parseOrbits(r)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(tree.Checksum()), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	tree, err := parseOrbits(r)
+	if err != nil {
+		return nil, err
+	}
+	n, err := tree.Transfers(you, santa)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(n), nil
+}
@@ -0,0 +1,43 @@
+package day06
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func TestOrbits(t *testing.T) {
+	a, err := Part1(testutil.ReadFile(t, "orbits.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != aoc.Int(42) {
+		t.Fatalf("expected 42, got %v", a)
+	}
+}
+
+func TestTransfers(t *testing.T) {
+	a, err := Part2(testutil.ReadFile(t, "transfers.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != aoc.Int(4) {
+		t.Fatalf("expected 4, got %v", a)
+	}
+	if _, err := Part2(strings.NewReader("COM)B\n")); err == nil {
+		t.Fatal("missing YOU and SAN accepted")
+	}
+}
+
+func TestFuzzParseOrbits(t *testing.T) {
+	tokens := []string{"COM", "YOU", "SAN", "B", ")", " ", "\n"}
+	if err := testutil.Fuzz(tokens, func(r io.Reader) error {
+		_, err := parseOrbits(r)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
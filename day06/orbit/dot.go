@@ -0,0 +1,61 @@
+package orbit
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// WriteDOT writes the tree as a Graphviz digraph with an edge from each
+// center to the bodies orbiting it, highlighting the orbits which join the
+// given bodies, such as YOU and SAN
+func (t *Tree) WriteDOT(w io.Writer, highlight ...string) error {
+	ends := make([]int, 0, len(highlight))
+	for _, i := range highlight {
+		k, err := t.lookup(i)
+		if err != nil {
+			return err
+		}
+		ends = append(ends, k)
+	}
+	marked := map[int]struct{}{}
+	if len(ends) > 0 {
+		c := ends[0]
+		for _, i := range ends[1:] {
+			c = t.lca(c, i)
+		}
+		for _, k := range ends {
+			for ; k != c; k = t.parent[k] {
+				marked[k] = struct{}{}
+			}
+		}
+		marked[c] = struct{}{}
+	}
+	b := bufio.NewWriter(w)
+	b.WriteString("digraph orbits {\n")
+	fmt.Fprintf(b, "  %q [shape=doublecircle];\n", t.names[0])
+	for k := range t.names {
+		if _, ok := marked[k]; ok {
+			fmt.Fprintf(b, "  %q [style=filled, fillcolor=gold];\n", t.names[k])
+		}
+	}
+	// bodies are written breadth first, so that each level of the tree is
+	// written together
+	queue := []int{0}
+	for len(queue) > 0 {
+		k := queue[0]
+		queue = queue[1:]
+		for _, i := range t.children[k] {
+			_, ok1 := marked[k]
+			_, ok2 := marked[i]
+			if ok1 && ok2 {
+				fmt.Fprintf(b, "  %q -> %q [color=gold, penwidth=2];\n", t.names[k], t.names[i])
+			} else {
+				fmt.Fprintf(b, "  %q -> %q;\n", t.names[k], t.names[i])
+			}
+			queue = append(queue, i)
+		}
+	}
+	b.WriteString("}\n")
+	return b.Flush()
+}
@@ -0,0 +1,24 @@
+package orbit
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2019/parse"
+)
+
+// Parse reads one orbit per line, such as A)B for B orbiting A
+func Parse(r io.Reader) ([]Orbit, error) {
+	pairs, err := parse.Pairs(r, ")")
+	if err != nil {
+		return nil, err
+	}
+	orbits := make([]Orbit, 0, len(pairs))
+	for _, i := range pairs {
+		orbits = append(orbits, Orbit{
+			Center: i.Left.Text,
+			Body:   i.Right.Text,
+			Pos:    i.Left.Pos,
+		})
+	}
+	return orbits, nil
+}
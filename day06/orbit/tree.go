@@ -0,0 +1,309 @@
+package orbit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xorkevin/advent2019/parse"
+)
+
+var (
+	ErrParents      = errors.New("body orbits more than one body")
+	ErrCycle        = errors.New("orbits form a cycle")
+	ErrDisconnected = errors.New("body does not orbit the root")
+	ErrRoot         = errors.New("root orbits another body")
+	ErrUnknown      = errors.New("unknown body")
+	ErrNoCenter     = errors.New("body orbits nothing")
+)
+
+const (
+	COM = "COM"
+)
+
+type (
+	// Orbit is a body directly orbiting a center, at the position of its line
+	// in the input
+	Orbit struct {
+		Center string
+		Body   string
+		Pos    parse.Pos
+	}
+
+	// Tree is a validated set of orbits, where every body other than the root
+	// orbits exactly one body and eventually the root. Bodies are indexed in
+	// the order they first appear, with the root first.
+	Tree struct {
+		names  []string
+		index  map[string]int
+		parent []int
+		depth  []int
+		// up[k][i] is the ancestor 2^k levels above body i, or the root
+		up       [][]int
+		children [][]int
+		orbits   []Orbit
+	}
+)
+
+// NewTree validates the orbits around the root, rejecting bodies with more
+// than one center, cycles and bodies which do not orbit the root
+func NewTree(orbits []Orbit, root string) (*Tree, error) {
+	t := &Tree{
+		index:  map[string]int{},
+		orbits: orbits,
+	}
+	t.add(root)
+	// by tracks the orbit giving each body its center
+	by := []int{-1}
+	for n, i := range orbits {
+		c := t.add(i.Center)
+		b := t.add(i.Body)
+		for len(by) < len(t.names) {
+			by = append(by, -1)
+		}
+		if b == 0 {
+			return nil, &parse.Error{
+				Pos: i.Pos,
+				Err: fmt.Errorf("%w: %s orbits %s", ErrRoot, i.Body, i.Center),
+			}
+		}
+		if k := by[b]; k >= 0 {
+			return nil, &parse.Error{
+				Pos: i.Pos,
+				Err: fmt.Errorf("%w: %s orbits %s and %s on line %d", ErrParents, i.Body, i.Center, orbits[k].Center, orbits[k].Pos.Line),
+			}
+		}
+		by[b] = n
+		t.parent[b] = c
+	}
+	t.parent[0] = 0
+	if err := t.setDepths(by); err != nil {
+		return nil, err
+	}
+	t.setUp()
+	t.children = make([][]int, len(t.names))
+	for i := 1; i < len(t.names); i++ {
+		p := t.parent[i]
+		t.children[p] = append(t.children[p], i)
+	}
+	return t, nil
+}
+
+func (t *Tree) add(name string) int {
+	if k, ok := t.index[name]; ok {
+		return k
+	}
+	k := len(t.names)
+	t.index[name] = k
+	t.names = append(t.names, name)
+	t.parent = append(t.parent, -1)
+	return k
+}
+
+// setDepths walks up from each body to a body of known depth, without
+// recursing, so that deep trees do not grow the stack
+func (t *Tree) setDepths(by []int) error {
+	const (
+		unseen = iota
+		walking
+		done
+	)
+	t.depth = make([]int, len(t.names))
+	state := make([]int, len(t.names))
+	state[0] = done
+	stack := []int{}
+	for i := range t.names {
+		stack = stack[:0]
+		k := i
+		for state[k] == unseen {
+			state[k] = walking
+			stack = append(stack, k)
+			if t.parent[k] < 0 {
+				o := t.centerOf(k)
+				return &parse.Error{
+					Pos: o.Pos,
+					Err: fmt.Errorf("%w: %s orbits nothing", ErrDisconnected, o.Center),
+				}
+			}
+			k = t.parent[k]
+		}
+		if state[k] == walking {
+			o := t.orbits[by[k]]
+			return &parse.Error{
+				Pos: o.Pos,
+				Err: fmt.Errorf("%w: through %s", ErrCycle, o.Body),
+			}
+		}
+		d := t.depth[k]
+		for n := len(stack) - 1; n >= 0; n-- {
+			d++
+			t.depth[stack[n]] = d
+			state[stack[n]] = done
+		}
+	}
+	return nil
+}
+
+// centerOf returns the first orbit around body k
+func (t *Tree) centerOf(k int) Orbit {
+	for _, i := range t.orbits {
+		if i.Center == t.names[k] {
+			return i
+		}
+	}
+	return Orbit{}
+}
+
+// setUp builds the table of ancestors at each power of two levels above every
+// body, for binary lifting
+func (t *Tree) setUp() {
+	maxDepth := 0
+	for _, i := range t.depth {
+		if i > maxDepth {
+			maxDepth = i
+		}
+	}
+	t.up = [][]int{t.parent}
+	for s := 1; s <= maxDepth; s *= 2 {
+		prev := t.up[len(t.up)-1]
+		next := make([]int, len(prev))
+		for i, p := range prev {
+			next[i] = prev[p]
+		}
+		t.up = append(t.up, next)
+	}
+}
+
+func (t *Tree) lookup(body string) (int, error) {
+	k, ok := t.index[body]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknown, body)
+	}
+	return k, nil
+}
+
+// Len is the number of bodies, including the root
+func (t *Tree) Len() int {
+	return len(t.names)
+}
+
+func (t *Tree) Root() string {
+	return t.names[0]
+}
+
+func (t *Tree) Has(body string) bool {
+	_, ok := t.index[body]
+	return ok
+}
+
+// Center returns the body which a body directly orbits
+func (t *Tree) Center(body string) (string, error) {
+	k, err := t.lookup(body)
+	if err != nil {
+		return "", err
+	}
+	if k == 0 {
+		return "", fmt.Errorf("%w: %s is the root", ErrNoCenter, body)
+	}
+	return t.names[t.parent[k]], nil
+}
+
+// Depth is the number of direct and indirect orbits of a body
+func (t *Tree) Depth(body string) (int, error) {
+	k, err := t.lookup(body)
+	if err != nil {
+		return 0, err
+	}
+	return t.depth[k], nil
+}
+
+// Checksum is the total number of direct and indirect orbits
+func (t *Tree) Checksum() int {
+	c := 0
+	for _, i := range t.depth {
+		c += i
+	}
+	return c
+}
+
+// Path returns the bodies from the root down to a body
+func (t *Tree) Path(body string) ([]string, error) {
+	k, err := t.lookup(body)
+	if err != nil {
+		return nil, err
+	}
+	path := make([]string, t.depth[k]+1)
+	for n := len(path) - 1; n >= 0; n-- {
+		path[n] = t.names[k]
+		k = t.parent[k]
+	}
+	return path, nil
+}
+
+// ancestor is the body n levels above body k
+func (t *Tree) ancestor(k, n int) int {
+	for i := 0; n > 0; i++ {
+		if n&1 != 0 {
+			k = t.up[i][k]
+		}
+		n >>= 1
+	}
+	return k
+}
+
+func (t *Tree) lca(a, b int) int {
+	if t.depth[a] < t.depth[b] {
+		a, b = b, a
+	}
+	a = t.ancestor(a, t.depth[a]-t.depth[b])
+	if a == b {
+		return a
+	}
+	for i := len(t.up) - 1; i >= 0; i-- {
+		if t.up[i][a] != t.up[i][b] {
+			a = t.up[i][a]
+			b = t.up[i][b]
+		}
+	}
+	return t.parent[a]
+}
+
+// LCA returns the deepest body which both bodies orbit, or either body
+// itself, in O(log n)
+func (t *Tree) LCA(a, b string) (string, error) {
+	i, err := t.lookup(a)
+	if err != nil {
+		return "", err
+	}
+	j, err := t.lookup(b)
+	if err != nil {
+		return "", err
+	}
+	return t.names[t.lca(i, j)], nil
+}
+
+// Distance is the number of orbits between two bodies
+func (t *Tree) Distance(a, b string) (int, error) {
+	i, err := t.lookup(a)
+	if err != nil {
+		return 0, err
+	}
+	j, err := t.lookup(b)
+	if err != nil {
+		return 0, err
+	}
+	return t.depth[i] + t.depth[j] - 2*t.depth[t.lca(i, j)], nil
+}
+
+// Transfers is the number of orbital transfers needed to move from the body
+// that a orbits to the body that b orbits
+func (t *Tree) Transfers(a, b string) (int, error) {
+	i, err := t.Center(a)
+	if err != nil {
+		return 0, err
+	}
+	j, err := t.Center(b)
+	if err != nil {
+		return 0, err
+	}
+	return t.Distance(i, j)
+}
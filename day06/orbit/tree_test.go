@@ -0,0 +1,217 @@
+package orbit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/parse"
+)
+
+const (
+	example = `COM)B
+B)C
+C)D
+D)E
+E)F
+B)G
+G)H
+D)I
+E)J
+J)K
+K)L
+K)YOU
+I)SAN
+`
+)
+
+func parseTree(t *testing.T, input string) *Tree {
+	t.Helper()
+	orbits, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := NewTree(orbits, COM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+func TestTree(t *testing.T) {
+	tree := parseTree(t, example)
+	if tree.Len() != 14 {
+		t.Fatalf("expected 14 bodies, got %d", tree.Len())
+	}
+	if c := tree.Checksum(); c != 54 {
+		t.Fatalf("expected checksum 54, got %d", c)
+	}
+	for _, tc := range []struct {
+		a, b     string
+		lca      string
+		distance int
+	}{
+		{a: "YOU", b: "SAN", lca: "D", distance: 6},
+		{a: "L", b: "H", lca: "B", distance: 8},
+		{a: "F", b: "K", lca: "E", distance: 3},
+		{a: "COM", b: "L", lca: "COM", distance: 7},
+		{a: "K", b: "L", lca: "K", distance: 1},
+		{a: "L", b: "L", lca: "L", distance: 0},
+	} {
+		t.Run(tc.a+"-"+tc.b, func(t *testing.T) {
+			lca, err := tree.LCA(tc.a, tc.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if lca != tc.lca {
+				t.Fatalf("expected %v, got %v", tc.lca, lca)
+			}
+			d, err := tree.Distance(tc.b, tc.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d != tc.distance {
+				t.Fatalf("expected %v, got %v", tc.distance, d)
+			}
+		})
+	}
+	if n, err := tree.Transfers("YOU", "SAN"); err != nil || n != 4 {
+		t.Fatalf("expected 4 transfers, got %d, %v", n, err)
+	}
+	path, err := tree.Path("SAN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"COM", "B", "C", "D", "I", "SAN"}; !reflect.DeepEqual(path, expected) {
+		t.Fatalf("expected %v, got %v", expected, path)
+	}
+	if _, err := tree.LCA("YOU", "nowhere"); !errors.Is(err, ErrUnknown) {
+		t.Fatalf("expected %v, got %v", ErrUnknown, err)
+	}
+	if _, err := tree.Transfers("COM", "SAN"); !errors.Is(err, ErrNoCenter) {
+		t.Fatalf("expected %v, got %v", ErrNoCenter, err)
+	}
+}
+
+// naiveLCA is the last common body of the paths from the root
+func naiveLCA(t *testing.T, tree *Tree, a, b string) string {
+	t.Helper()
+	x, err := tree.Path(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := tree.Path(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := 0
+	for k < len(x) && k < len(y) && x[k] == y[k] {
+		k++
+	}
+	return x[k-1]
+}
+
+func TestLCARandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, tc := range []struct {
+		name   string
+		bodies int
+		// span is how far back a body may pick its center, so that a small
+		// span makes a deep tree
+		span int
+	}{
+		{name: "wide", bodies: 2000, span: 2000},
+		{name: "deep", bodies: 2000, span: 3},
+		{name: "chain", bodies: 1500, span: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := strings.Builder{}
+			for i := 1; i < tc.bodies; i++ {
+				c := i - 1 - rng.Intn(tc.span)
+				if c < 0 {
+					c = 0
+				}
+				fmt.Fprintf(&b, "N%d)N%d\n", c, i)
+			}
+			orbits, err := Parse(strings.NewReader(b.String()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tree, err := NewTree(orbits, "N0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 500; i++ {
+				x := fmt.Sprintf("N%d", rng.Intn(tc.bodies))
+				y := fmt.Sprintf("N%d", rng.Intn(tc.bodies))
+				lca, err := tree.LCA(x, y)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if expected := naiveLCA(t, tree, x, y); lca != expected {
+					t.Fatalf("%s, %s: expected %v, got %v", x, y, expected, lca)
+				}
+			}
+		})
+	}
+}
+
+func TestNewTreeInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input string
+		err   error
+		line  int
+	}{
+		{name: "parents", input: "COM)A\nCOM)B\nA)C\nB)C\n", err: ErrParents, line: 4},
+		{name: "cycle", input: "COM)A\nB)C\nC)D\nD)B\n", err: ErrCycle, line: 4},
+		{name: "self", input: "COM)A\nB)B\n", err: ErrCycle, line: 2},
+		{name: "disconnected", input: "COM)A\nX)Y\nY)Z\n", err: ErrDisconnected, line: 2},
+		{name: "root", input: "COM)A\nA)COM\n", err: ErrRoot, line: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			orbits, err := Parse(strings.NewReader(tc.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = NewTree(orbits, COM)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+			var perr *parse.Error
+			if !errors.As(err, &perr) || perr.Line != tc.line {
+				t.Fatalf("expected error on line %d, got %v", tc.line, err)
+			}
+		})
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	tree := parseTree(t, "COM)A\nA)B\nCOM)C\nA)D\n")
+	b := bytes.Buffer{}
+	if err := tree.WriteDOT(&b, "B", "C"); err != nil {
+		t.Fatal(err)
+	}
+	expected := `digraph orbits {
+  "COM" [shape=doublecircle];
+  "COM" [style=filled, fillcolor=gold];
+  "A" [style=filled, fillcolor=gold];
+  "B" [style=filled, fillcolor=gold];
+  "C" [style=filled, fillcolor=gold];
+  "COM" -> "A" [color=gold, penwidth=2];
+  "COM" -> "C" [color=gold, penwidth=2];
+  "A" -> "B" [color=gold, penwidth=2];
+  "A" -> "D";
+}
+`
+	if b.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, b.String())
+	}
+	if err := tree.WriteDOT(&b, "nowhere"); !errors.Is(err, ErrUnknown) {
+		t.Fatalf("expected %v, got %v", ErrUnknown, err)
+	}
+}
@@ -0,0 +1,121 @@
+package day07
+
+import (
+	"errors"
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/intcode"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+var (
+	ErrNoSignal = errors.New("amplifier halted without a signal")
+)
+
+func Perm(a []int, f func([]int)) {
+	perm(a, f, 0)
+}
+
+// Permute the values at index i to len(a)-1.
+func perm(a []int, f func([]int), i int) {
+	if i > len(a) {
+		f(a)
+		return
+	}
+	perm(a, f, i+1)
+	for j := i + 1; j < len(a); j++ {
+		a[i], a[j] = a[j], a[i]
+		perm(a, f, i+1)
+		a[i], a[j] = a[j], a[i]
+	}
+}
+
+func runAmps(tokens []int, phases []int) (int, error) {
+	out := 0
+	for _, phase := range phases {
+		mem := make([]int, len(tokens))
+		copy(mem, tokens)
+		m := intcode.NewMachine(mem)
+		m.Write(phase)
+		m.Write(out)
+		v, ok := m.Read()
+		if !ok {
+			if err := m.Err(); err != nil {
+				return 0, err
+			}
+			return 0, ErrNoSignal
+		}
+		out = v
+	}
+	return out, nil
+}
+
+// runFeedback passes each output to the next amp in turn, until the amps
+// halt, and returns the last output of the last amp
+func runFeedback(tokens []int, phases []int) (int, error) {
+	m := make([]*intcode.Machine, 0, len(phases))
+	for _, phase := range phases {
+		mem := make([]int, len(tokens))
+		copy(mem, tokens)
+		k := intcode.NewMachine(mem)
+		k.Write(phase)
+		m = append(m, k)
+	}
+
+	out := 0
+	received := false
+	for {
+		for n, k := range m {
+			k.Write(out)
+			v, ok := k.Read()
+			if !ok {
+				if err := k.Err(); err != nil {
+					return 0, err
+				}
+				if !received {
+					return 0, ErrNoSignal
+				}
+				return out, nil
+			}
+			out = v
+			if n == len(m)-1 {
+				received = true
+			}
+		}
+	}
+}
+
+func maxSignal(r io.Reader, phases []int, run func(tokens []int, phases []int) (int, error)) (aoc.Answer, error) {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return nil, err
+	}
+	maxOut := 0
+	var runErr error
+	Perm(phases, func(phases []int) {
+		if runErr != nil {
+			return
+		}
+		out, err := run(tokens, phases)
+		if err != nil {
+			runErr = err
+			return
+		}
+		if out > maxOut {
+			maxOut = out
+		}
+	})
+	if runErr != nil {
+		return nil, runErr
+	}
+	return aoc.Int(maxOut), nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	return maxSignal(r, []int{0, 1, 2, 3, 4}, runAmps)
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	return maxSignal(r, []int{5, 6, 7, 8, 9}, runFeedback)
+}
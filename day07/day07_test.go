@@ -0,0 +1,107 @@
+package day07
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/intcode"
+	"github.com/xorkevin/advent2019/internal/testutil"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func TestPerm(t *testing.T) {
+	seen := map[[3]int]bool{}
+	Perm([]int{1, 2, 3}, func(a []int) {
+		seen[[3]int{a[0], a[1], a[2]}] = true
+	})
+	if len(seen) != 6 {
+		t.Fatalf("expected 6 permutations, got %d", len(seen))
+	}
+}
+
+func TestAmps(t *testing.T) {
+	for _, tc := range []struct {
+		file     string
+		phases   []int
+		expected int
+	}{
+		{file: "chain1.txt", phases: []int{4, 3, 2, 1, 0}, expected: 43210},
+		{file: "chain2.txt", phases: []int{0, 1, 2, 3, 4}, expected: 54321},
+		{file: "chain3.txt", phases: []int{1, 0, 4, 3, 2}, expected: 65210},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			tokens, err := parse.CSVInts(testutil.ReadFile(t, tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := runAmps(tokens, tc.phases)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != tc.expected {
+				t.Errorf("phases %v: expected %d, got %d", tc.phases, tc.expected, out)
+			}
+			a, err := Part1(testutil.ReadFile(t, tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a != aoc.Int(tc.expected) {
+				t.Errorf("max signal: expected %d, got %v", tc.expected, a)
+			}
+		})
+	}
+}
+
+func TestFeedback(t *testing.T) {
+	for _, tc := range []struct {
+		file     string
+		phases   []int
+		expected int
+	}{
+		{file: "feedback1.txt", phases: []int{9, 8, 7, 6, 5}, expected: 139629729},
+		{file: "feedback2.txt", phases: []int{9, 7, 8, 5, 6}, expected: 18216},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			tokens, err := parse.CSVInts(testutil.ReadFile(t, tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := runFeedback(tokens, tc.phases)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != tc.expected {
+				t.Errorf("phases %v: expected %d, got %d", tc.phases, tc.expected, out)
+			}
+			a, err := Part2(testutil.ReadFile(t, tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a != aoc.Int(tc.expected) {
+				t.Errorf("max signal: expected %d, got %v", tc.expected, a)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		part     aoc.PartFunc
+		prog     string
+		expected error
+	}{
+		{name: "illegal op", part: Part1, prog: "3,0,3,1,42", expected: intcode.ErrIllegalOp},
+		{name: "no signal", part: Part1, prog: "3,0,3,1,99", expected: ErrNoSignal},
+		{name: "feedback no signal", part: Part2, prog: "3,0,3,1,99", expected: ErrNoSignal},
+		{name: "feedback no input", part: Part2, prog: "3,0,3,1,3,2,4,0,99", expected: intcode.ErrNoInput},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.part(strings.NewReader(tc.prog)); !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
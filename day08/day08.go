@@ -0,0 +1,33 @@
+package day08
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day08/sif"
+)
+
+const (
+	imgWidth  = 25
+	imgHeight = 6
+)
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	img, err := sif.Decode(r, imgWidth, imgHeight)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(img.Checksum()), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	img, err := sif.Decode(r, imgWidth, imgHeight)
+	if err != nil {
+		return nil, err
+	}
+	text, err := img.Render().Text()
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Text(text), nil
+}
@@ -0,0 +1,20 @@
+package day08
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/day08/sif"
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func TestAnswers(t *testing.T) {
+	testutil.CheckAnswers(t, Part1, Part2)
+}
+
+func TestPartialLayer(t *testing.T) {
+	if _, err := Part1(strings.NewReader("0120\n")); !errors.Is(err, sif.ErrPartialLayer) {
+		t.Fatalf("expected %v, got %v", sif.ErrPartialLayer, err)
+	}
+}
@@ -0,0 +1,51 @@
+package sif
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+
+	"github.com/xorkevin/advent2019/ocr"
+)
+
+var (
+	palette = color.Palette{
+		Black:       color.Black,
+		White:       color.White,
+		Transparent: color.Transparent,
+	}
+)
+
+// Image converts the layer to an image, with each pixel scaled up to a
+// square of scale by scale
+func (l Layer) Image(scale int) *image.Paletted {
+	if scale < 1 {
+		scale = 1
+	}
+	h := len(l)
+	w := 0
+	if h > 0 {
+		w = len(l[0])
+	}
+	img := image.NewPaletted(image.Rect(0, 0, w*scale, h*scale), palette)
+	for y, i := range l {
+		for x, j := range i {
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex(x*scale+dx, y*scale+dy, uint8(j))
+				}
+			}
+		}
+	}
+	return img
+}
+
+func (l Layer) WritePNG(w io.Writer, scale int) error {
+	return png.Encode(w, l.Image(scale))
+}
+
+// Text reads the block letters drawn in white
+func (l Layer) Text() (string, error) {
+	return ocr.ParseInts(l, White)
+}
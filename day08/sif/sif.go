@@ -0,0 +1,177 @@
+package sif
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xorkevin/advent2019/parse"
+)
+
+var (
+	ErrDimensions   = errors.New("invalid image dimensions")
+	ErrEmpty        = errors.New("image has no layers")
+	ErrPartialLayer = errors.New("image data ends partway through a layer")
+	ErrBadDigit     = errors.New("invalid pixel digit")
+)
+
+const (
+	Black       = 0
+	White       = 1
+	Transparent = 2
+)
+
+type (
+	Layer [][]int
+
+	// Image is a Space Image Format image of one or more layers, the first
+	// of which is in front
+	Image struct {
+		Width  int
+		Height int
+		Layers []Layer
+	}
+)
+
+func NewLayer(w, h int) Layer {
+	layer := make(Layer, 0, h)
+	for i := 0; i < h; i++ {
+		layer = append(layer, make([]int, w))
+	}
+	return layer
+}
+
+func (l Layer) CountNum(num int) int {
+	count := 0
+	for _, i := range l {
+		for _, j := range i {
+			if j == num {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func (l Layer) String() string {
+	b := strings.Builder{}
+	for _, i := range l {
+		for _, j := range i {
+			if j == White {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Decode reads a line of pixel digits as layers of w by h pixels.
+// Surrounding whitespace is ignored, but any other data that does not fill a
+// whole layer is an error.
+func Decode(r io.Reader, w, h int) (*Image, error) {
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("%w: %dx%d", ErrDimensions, w, h)
+	}
+	line, err := parse.Line(r)
+	var perr *parse.Error
+	if errors.Is(err, parse.ErrNoData) && errors.As(err, &perr) {
+		return nil, &parse.Error{
+			Pos: perr.Pos,
+			Err: fmt.Errorf("%w: %v", ErrEmpty, perr.Err),
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	data := line.Text
+	size := w * h
+	if len(data)%size != 0 {
+		return nil, line.EndErrorf("%w: %d trailing pixels after %d layers of %d", ErrPartialLayer, len(data)%size, len(data)/size, size)
+	}
+	img := &Image{
+		Width:  w,
+		Height: h,
+		Layers: make([]Layer, 0, len(data)/size),
+	}
+	for n := 0; n < len(data); n++ {
+		i := data[n]
+		if i < '0' || i > '2' {
+			return nil, line.Sub(n, n+1).Errorf("%w: %q", ErrBadDigit, i)
+		}
+		if n%size == 0 {
+			img.Layers = append(img.Layers, NewLayer(w, h))
+		}
+		k := n % size
+		img.Layers[n/size][k/w][k%w] = int(i - '0')
+	}
+	return img, nil
+}
+
+// Encode writes every layer of the image as a single line of pixel digits
+func Encode(wr io.Writer, img *Image) error {
+	if img.Width <= 0 || img.Height <= 0 {
+		return fmt.Errorf("%w: %dx%d", ErrDimensions, img.Width, img.Height)
+	}
+	if len(img.Layers) == 0 {
+		return ErrEmpty
+	}
+	b := bufio.NewWriter(wr)
+	for n, l := range img.Layers {
+		if len(l) != img.Height {
+			return fmt.Errorf("%w: layer %d has %d rows", ErrDimensions, n, len(l))
+		}
+		for _, i := range l {
+			if len(i) != img.Width {
+				return fmt.Errorf("%w: layer %d has a row of %d pixels", ErrDimensions, n, len(i))
+			}
+			for _, j := range i {
+				if j < Black || j > Transparent {
+					return fmt.Errorf("%w: %d in layer %d", ErrBadDigit, j, n)
+				}
+				b.WriteByte(byte('0' + j))
+			}
+		}
+	}
+	b.WriteByte('\n')
+	return b.Flush()
+}
+
+// Render stacks the layers, with each pixel taking the color of the first
+// layer that is not transparent there
+func (m *Image) Render() Layer {
+	layer := NewLayer(m.Width, m.Height)
+	for i := 0; i < m.Height; i++ {
+		for j := 0; j < m.Width; j++ {
+			layer[i][j] = Transparent
+			for _, k := range m.Layers {
+				if v := k[i][j]; v != Transparent {
+					layer[i][j] = v
+					break
+				}
+			}
+		}
+	}
+	return layer
+}
+
+// Checksum finds the layer with the fewest black pixels and multiplies its
+// number of white pixels by its number of transparent pixels
+func (m *Image) Checksum() int {
+	if len(m.Layers) == 0 {
+		return 0
+	}
+	layer := 0
+	minZeros := m.Layers[0].CountNum(Black)
+	for n, i := range m.Layers[1:] {
+		if count := i.CountNum(Black); count < minZeros {
+			layer = n + 1
+			minZeros = count
+		}
+	}
+	return m.Layers[layer].CountNum(White) * m.Layers[layer].CountNum(Transparent)
+}
@@ -0,0 +1,108 @@
+package sif
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func randImage(r *rand.Rand) *Image {
+	w := r.Intn(8) + 1
+	h := r.Intn(8) + 1
+	n := r.Intn(5) + 1
+	img := &Image{
+		Width:  w,
+		Height: h,
+		Layers: make([]Layer, 0, n),
+	}
+	for i := 0; i < n; i++ {
+		l := NewLayer(w, h)
+		for _, j := range l {
+			for k := range j {
+				j[k] = r.Intn(3)
+			}
+		}
+		img.Layers = append(img.Layers, l)
+	}
+	return img
+}
+
+func TestEncodeDecode(t *testing.T) {
+	roundTrip := func(seed int64) bool {
+		img := randImage(rand.New(rand.NewSource(seed)))
+		b := bytes.Buffer{}
+		if err := Encode(&b, img); err != nil {
+			t.Log(err)
+			return false
+		}
+		out, err := Decode(&b, img.Width, img.Height)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+		return reflect.DeepEqual(img, out)
+	}
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input string
+		w, h  int
+		err   error
+	}{
+		{name: "two layers", input: "012210\n", w: 3, h: 1},
+		{name: "partial layer", input: "0122101", w: 3, h: 1, err: ErrPartialLayer},
+		{name: "bad digit", input: "012310", w: 3, h: 1, err: ErrBadDigit},
+		{name: "empty", input: "\n", w: 3, h: 1, err: ErrEmpty},
+		{name: "dimensions", input: "012", w: 0, h: 1, err: ErrDimensions},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(tc.input), tc.w, tc.h)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	img, err := Decode(strings.NewReader("0222112222120000"), 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Layer{{0, 1}, {1, 0}}
+	if out := img.Render(); !reflect.DeepEqual(out, expected) {
+		t.Fatalf("expected %v, got %v", expected, out)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	img, err := Decode(strings.NewReader("001122012222"), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := img.Checksum(); out != 4 {
+		t.Fatalf("expected checksum 4, got %d", out)
+	}
+}
+
+func TestFuzzDecode(t *testing.T) {
+	tokens := []string{"0", "1", "2", "012", "3", " ", "\n"}
+	if err := testutil.Fuzz(tokens, func(r io.Reader) error {
+		_, err := Decode(r, 3, 2)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,66 @@
+package day09
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/intcode"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+const (
+	ramSize = 8192
+)
+
+var (
+	ErrMalfunction = errors.New("program reported malfunctioning opcodes")
+)
+
+func Perm(a []int, f func([]int)) {
+	perm(a, f, 0)
+}
+
+// Permute the values at index i to len(a)-1.
+func perm(a []int, f func([]int), i int) {
+	if i > len(a) {
+		f(a)
+		return
+	}
+	perm(a, f, i+1)
+	for j := i + 1; j < len(a); j++ {
+		a[i], a[j] = a[j], a[i]
+		perm(a, f, i+1)
+		a[i], a[j] = a[j], a[i]
+	}
+}
+
+// boost runs the BOOST program in a mode and returns its keycode, the only
+// value it outputs when every opcode works
+func boost(r io.Reader, mode int) (aoc.Answer, error) {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return nil, err
+	}
+	mem := make([]int, ramSize)
+	copy(mem, tokens)
+	m := intcode.NewMachine(mem)
+	m.Write(mode)
+	outs, err := m.Outputs()
+	if err != nil {
+		return nil, err
+	}
+	if len(outs) != 1 {
+		return nil, fmt.Errorf("%w: %v", ErrMalfunction, outs)
+	}
+	return aoc.Int(outs[0]), nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	return boost(r, 1)
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	return boost(r, 2)
+}
@@ -0,0 +1,81 @@
+package day09
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/intcode"
+	"github.com/xorkevin/advent2019/internal/testutil"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func run(t *testing.T, name string) []int {
+	t.Helper()
+	tokens, err := parse.CSVInts(testutil.ReadFile(t, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mem := make([]int, ramSize)
+	copy(mem, tokens)
+	outs, err := intcode.NewMachine(mem).Outputs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return outs
+}
+
+func TestQuine(t *testing.T) {
+	expected, err := parse.CSVInts(testutil.ReadFile(t, "quine.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outs := run(t, "quine.txt"); !reflect.DeepEqual(outs, expected) {
+		t.Fatalf("expected %v, got %v", expected, outs)
+	}
+}
+
+func TestLargeNumbers(t *testing.T) {
+	for _, tc := range []struct {
+		file     string
+		expected int
+	}{
+		{file: "large.txt", expected: 1219070632396864},
+		{file: "middle.txt", expected: 1125899906842624},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			if outs := run(t, tc.file); !reflect.DeepEqual(outs, []int{tc.expected}) {
+				t.Fatalf("expected [%d], got %v", tc.expected, outs)
+			}
+			a, err := boost(testutil.ReadFile(t, tc.file), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a != aoc.Int(tc.expected) {
+				t.Fatalf("expected %d, got %v", tc.expected, a)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		prog     string
+		expected error
+	}{
+		{name: "illegal op", prog: "104,1,77", expected: intcode.ErrIllegalOp},
+		{name: "illegal mode", prog: "304,1,99", expected: intcode.ErrIllegalMode},
+		{name: "relative write", prog: "109,-3,203,0,99", expected: intcode.ErrMemBounds},
+		{name: "no input", prog: "3,0,3,0,99", expected: intcode.ErrNoInput},
+		{name: "malfunction", prog: "104,0,104,1,99", expected: ErrMalfunction},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := boost(strings.NewReader(tc.prog), 1); !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
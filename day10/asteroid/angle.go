@@ -0,0 +1,62 @@
+package asteroid
+
+import (
+	"github.com/xorkevin/advent2019/grid"
+)
+
+type (
+	// Angle is a direction reduced to lowest terms, so that every asteroid
+	// along the same line of sight has the same angle
+	Angle struct {
+		dx, dy int
+	}
+)
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// NewAngle returns the direction from a to b and the number of steps of that
+// direction between them
+func NewAngle(a, b grid.Point) (Angle, int) {
+	dx := b.X - a.X
+	dy := b.Y - a.Y
+	g := gcd(abs(dx), abs(dy))
+	if g == 0 {
+		return Angle{}, 0
+	}
+	return Angle{
+		dx: dx / g,
+		dy: dy / g,
+	}, g
+}
+
+// half is 0 for angles from straight up clockwise to just before straight
+// down, and 1 for the rest
+func (a Angle) half() int {
+	if a.dx > 0 || (a.dx == 0 && a.dy < 0) {
+		return 0
+	}
+	return 1
+}
+
+// Less orders angles clockwise starting from straight up, where y increases
+// downwards. Angles in the same half turn are compared by the sign of their
+// cross product, which compares their slopes exactly.
+func (a Angle) Less(b Angle) bool {
+	ha, hb := a.half(), b.half()
+	if ha != hb {
+		return ha < hb
+	}
+	return a.dx*b.dy-a.dy*b.dx > 0
+}
@@ -0,0 +1,137 @@
+package asteroid
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/grid"
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func parseFile(t *testing.T, name string) *Field {
+	t.Helper()
+	f, err := Parse(testutil.ReadFile(t, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestBest(t *testing.T) {
+	for _, tc := range []struct {
+		file    string
+		station grid.Point
+		visible int
+	}{
+		{file: "small.txt", station: grid.Point{X: 3, Y: 4}, visible: 8},
+		{file: "medium1.txt", station: grid.Point{X: 5, Y: 8}, visible: 33},
+		{file: "medium2.txt", station: grid.Point{X: 1, Y: 2}, visible: 35},
+		{file: "medium3.txt", station: grid.Point{X: 6, Y: 3}, visible: 41},
+		{file: "large.txt", station: grid.Point{X: 11, Y: 13}, visible: 210},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			f := parseFile(t, tc.file)
+			station, visible, ok := f.Best()
+			if !ok {
+				t.Fatal("no asteroids")
+			}
+			if station != tc.station || visible != tc.visible {
+				t.Fatalf("expected %v with %d visible, got %v with %d", tc.station, tc.visible, station, visible)
+			}
+		})
+	}
+}
+
+func TestVaporize(t *testing.T) {
+	for _, tc := range []struct {
+		file     string
+		station  grid.Point
+		expected map[int]grid.Point
+		total    int
+	}{
+		{
+			file:    "laser.txt",
+			station: grid.Point{X: 8, Y: 3},
+			expected: map[int]grid.Point{
+				1: {X: 8, Y: 1},
+				2: {X: 9, Y: 0},
+				3: {X: 9, Y: 1},
+				4: {X: 10, Y: 0},
+				5: {X: 9, Y: 2},
+				6: {X: 11, Y: 1},
+				7: {X: 12, Y: 1},
+				8: {X: 11, Y: 2},
+				9: {X: 15, Y: 1},
+			},
+			total: 36,
+		},
+		{
+			file:    "large.txt",
+			station: grid.Point{X: 11, Y: 13},
+			expected: map[int]grid.Point{
+				1:   {X: 11, Y: 12},
+				2:   {X: 12, Y: 1},
+				3:   {X: 12, Y: 2},
+				10:  {X: 12, Y: 8},
+				20:  {X: 16, Y: 0},
+				50:  {X: 16, Y: 9},
+				100: {X: 10, Y: 16},
+				199: {X: 9, Y: 6},
+				200: {X: 8, Y: 2},
+				201: {X: 10, Y: 9},
+				299: {X: 11, Y: 1},
+			},
+			total: 299,
+		},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			f := parseFile(t, tc.file)
+			v := f.Vaporize(tc.station)
+			n := 0
+			for {
+				k, ok := v.Next()
+				if !ok {
+					break
+				}
+				n++
+				if p, ok := tc.expected[n]; ok && p != k {
+					t.Errorf("expected asteroid %d to be %v, got %v", n, p, k)
+				}
+			}
+			if n != tc.total {
+				t.Fatalf("expected %d asteroids vaporized, got %d", tc.total, n)
+			}
+		})
+	}
+}
+
+func TestAngleLess(t *testing.T) {
+	origin := grid.Point{}
+	clockwise := []grid.Point{{X: 0, Y: -1}, {X: 1, Y: -2}, {X: 1, Y: -1}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: -1, Y: 1}, {X: -1, Y: 0}, {X: -1, Y: -1}, {X: -1, Y: -3}}
+	for i := range clockwise {
+		for j := range clockwise {
+			a, _ := NewAngle(origin, clockwise[i])
+			b, _ := NewAngle(origin, clockwise[j])
+			if a.Less(b) != (i < j) {
+				t.Errorf("expected %v before %v to be %t", clockwise[i], clockwise[j], i < j)
+			}
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	if _, err := Parse(strings.NewReader(".#\n#?\n")); err == nil {
+		t.Fatal("expected error for invalid character")
+	}
+}
+
+func TestFuzzParse(t *testing.T) {
+	tokens := []string{"#", ".", "X", "#.#", "?", "\n"}
+	if err := testutil.Fuzz(tokens, func(r io.Reader) error {
+		_, err := Parse(r)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,154 @@
+package asteroid
+
+import (
+	"io"
+	"sort"
+
+	"github.com/xorkevin/advent2019/grid"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+var (
+	ErrInvalidChar = parse.ErrChar
+)
+
+type (
+	Field struct {
+		Width     int
+		Height    int
+		Asteroids []grid.Point
+	}
+)
+
+func isAsteroid(c byte) bool {
+	return c == '#' || c == 'X'
+}
+
+// Parse reads a map of asteroids, where # or X is an asteroid and . is empty
+// space
+func Parse(r io.Reader) (*Field, error) {
+	g, err := parse.Grid(r, '.', func(c byte) bool {
+		return c == '.' || isAsteroid(c)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Field{
+		Width:     g.Width(),
+		Height:    g.Height(),
+		Asteroids: g.FindFunc(isAsteroid),
+	}, nil
+}
+
+// Visible counts the asteroids in direct line of sight of the station, which
+// is one distinct angle per visible asteroid
+func (f *Field) Visible(station grid.Point) int {
+	angles := make(map[Angle]struct{}, len(f.Asteroids))
+	for _, i := range f.Asteroids {
+		if i == station {
+			continue
+		}
+		a, _ := NewAngle(station, i)
+		angles[a] = struct{}{}
+	}
+	return len(angles)
+}
+
+// Best finds the asteroid from which the most asteroids are visible
+func (f *Field) Best() (grid.Point, int, bool) {
+	best := grid.Point{}
+	max := -1
+	for _, i := range f.Asteroids {
+		if k := f.Visible(i); k > max {
+			best = i
+			max = k
+		}
+	}
+	if max < 0 {
+		return grid.Point{}, 0, false
+	}
+	return best, max, true
+}
+
+type (
+	target struct {
+		pos  grid.Point
+		dist int
+	}
+
+	ray struct {
+		angle   Angle
+		targets []target
+	}
+
+	// Vaporizer yields asteroids in the order a laser rotating clockwise from
+	// straight up destroys them
+	Vaporizer struct {
+		rays []*ray
+		next int
+		left int
+	}
+)
+
+// Vaporize returns the vaporization order of every other asteroid from the
+// station
+func (f *Field) Vaporize(station grid.Point) *Vaporizer {
+	byAngle := map[Angle]*ray{}
+	rays := []*ray{}
+	left := 0
+	for _, i := range f.Asteroids {
+		if i == station {
+			continue
+		}
+		a, dist := NewAngle(station, i)
+		r, ok := byAngle[a]
+		if !ok {
+			r = &ray{
+				angle: a,
+			}
+			byAngle[a] = r
+			rays = append(rays, r)
+		}
+		r.targets = append(r.targets, target{
+			pos:  i,
+			dist: dist,
+		})
+		left++
+	}
+	sort.Slice(rays, func(i, j int) bool {
+		return rays[i].angle.Less(rays[j].angle)
+	})
+	for _, i := range rays {
+		t := i.targets
+		sort.Slice(t, func(i, j int) bool {
+			return t[i].dist < t[j].dist
+		})
+	}
+	return &Vaporizer{
+		rays: rays,
+		next: 0,
+		left: left,
+	}
+}
+
+// Next returns the next asteroid to be vaporized, and false once every
+// asteroid has been vaporized
+func (v *Vaporizer) Next() (grid.Point, bool) {
+	if v.left == 0 {
+		return grid.Point{}, false
+	}
+	for len(v.rays[v.next].targets) == 0 {
+		v.next = (v.next + 1) % len(v.rays)
+	}
+	r := v.rays[v.next]
+	k := r.targets[0].pos
+	r.targets = r.targets[1:]
+	v.next = (v.next + 1) % len(v.rays)
+	v.left--
+	return k, true
+}
+
+// Remaining returns the number of asteroids not yet vaporized
+func (v *Vaporizer) Remaining() int {
+	return v.left
+}
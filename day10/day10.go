@@ -0,0 +1,48 @@
+package day10
+
+import (
+	"errors"
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day10/asteroid"
+	"github.com/xorkevin/advent2019/grid"
+)
+
+var (
+	ErrNoAsteroids = errors.New("no asteroids")
+	ErrTooFew      = errors.New("fewer than 200 asteroids")
+)
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	field, err := asteroid.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	_, max, ok := field.Best()
+	if !ok {
+		return nil, ErrNoAsteroids
+	}
+	return aoc.Int(max), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	field, err := asteroid.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	station, _, ok := field.Best()
+	if !ok {
+		return nil, ErrNoAsteroids
+	}
+
+	v := field.Vaporize(station)
+	var k grid.Point
+	for i := 0; i < 200; i++ {
+		k, ok = v.Next()
+		if !ok {
+			return nil, ErrTooFew
+		}
+	}
+	return aoc.Int(k.X*100 + k.Y), nil
+}
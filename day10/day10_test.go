@@ -0,0 +1,43 @@
+package day10
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func TestSolve(t *testing.T) {
+	for _, tc := range []struct {
+		file     string
+		part     int
+		expected int
+	}{
+		{file: "small.txt", part: 1, expected: 8},
+		{file: "medium1.txt", part: 1, expected: 33},
+		{file: "medium2.txt", part: 1, expected: 35},
+		{file: "medium3.txt", part: 1, expected: 41},
+		{file: "large.txt", part: 1, expected: 210},
+		{file: "large.txt", part: 2, expected: 802},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			solve := Part1
+			if tc.part == 2 {
+				solve = Part2
+			}
+			// the samples are shared with the asteroid package
+			a, err := solve(testutil.ReadFile(t, filepath.Join("..", "asteroid", "testdata", tc.file)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a != aoc.Int(tc.expected) {
+				t.Fatalf("part %d: expected %d, got %v", tc.part, tc.expected, a)
+			}
+		})
+	}
+}
+
+func TestAnswers(t *testing.T) {
+	testutil.CheckAnswers(t, Part1, Part2)
+}
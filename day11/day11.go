@@ -0,0 +1,58 @@
+package day11
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day11/hull"
+	"github.com/xorkevin/advent2019/intcode"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+const (
+	ramSize = 8192
+)
+
+func paint(r io.Reader, start int) (*hull.Robot, error) {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return nil, err
+	}
+	robot := hull.NewRobot(hull.Standard)
+	if err := robot.Paint(start); err != nil {
+		return nil, err
+	}
+	mem := make([]int, ramSize)
+	copy(mem, tokens)
+	m := intcode.NewMachine(mem)
+	if err := robot.Run(m); err != nil {
+		if merr := m.Err(); merr != nil {
+			return nil, merr
+		}
+		return nil, err
+	}
+	if err := m.Err(); err != nil {
+		return nil, err
+	}
+	return robot, nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	robot, err := paint(r, hull.ColorBlack)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(robot.Painted()), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	robot, err := paint(r, hull.ColorWhite)
+	if err != nil {
+		return nil, err
+	}
+	id, err := robot.Identifier()
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Text(id), nil
+}
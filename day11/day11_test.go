@@ -0,0 +1,11 @@
+package day11
+
+import (
+	"testing"
+
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func TestAnswers(t *testing.T) {
+	testutil.CheckAnswers(t, Part1, Part2)
+}
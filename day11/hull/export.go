@@ -0,0 +1,87 @@
+package hull
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+)
+
+const (
+	asciiPalette = " #+*=-:."
+)
+
+// String renders the painted area as ASCII, with black as a space and white
+// as #
+func (r *Robot) String() string {
+	b := strings.Builder{}
+	for _, i := range r.Grid() {
+		for _, j := range i {
+			b.WriteByte(asciiPalette[j%len(asciiPalette)])
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// WritePBM writes the painted area as a plain PBM bitmap, with every non
+// black panel as ink
+func (r *Robot) WritePBM(w io.Writer) error {
+	grid := r.Grid()
+	width := 0
+	if len(grid) > 0 {
+		width = len(grid[0])
+	}
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "P1\n%d %d\n", width, len(grid))
+	for _, i := range grid {
+		for n, j := range i {
+			if n > 0 {
+				b.WriteByte(' ')
+			}
+			if j != ColorBlack {
+				b.WriteByte('1')
+			} else {
+				b.WriteByte('0')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.Flush()
+}
+
+func grayPalette(n int) color.Palette {
+	if n < 2 {
+		n = 2
+	}
+	p := make(color.Palette, 0, n)
+	for i := 0; i < n; i++ {
+		v := uint8(i * 255 / (n - 1))
+		p = append(p, color.Gray{Y: v})
+	}
+	return p
+}
+
+// Image returns the painted area with each color shaded evenly from black to
+// white
+func (r *Robot) Image() *image.Paletted {
+	grid := r.Grid()
+	width := 0
+	if len(grid) > 0 {
+		width = len(grid[0])
+	}
+	img := image.NewPaletted(image.Rect(0, 0, width, len(grid)), grayPalette(r.proto.Colors()))
+	for y, i := range grid {
+		for x, j := range i {
+			img.SetColorIndex(x, y, uint8(j))
+		}
+	}
+	return img
+}
+
+func (r *Robot) WritePNG(w io.Writer) error {
+	return png.Encode(w, r.Image())
+}
@@ -0,0 +1,80 @@
+package hull
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// exampleRobot paints the example from the puzzle
+func exampleRobot(t *testing.T) *Robot {
+	t.Helper()
+	r := NewRobot(Standard)
+	if err := r.Run(&script{out: []int{1, 0, 0, 0, 1, 0, 1, 0, 0, 1, 1, 0, 1, 0}}); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestString(t *testing.T) {
+	if s, expected := exampleRobot(t).String(), "  #\n  #\n## \n"; s != expected {
+		t.Fatalf("expected %q, got %q", expected, s)
+	}
+	if s := NewRobot(Standard).String(); s != "" {
+		t.Fatalf("expected nothing for an unpainted hull, got %q", s)
+	}
+}
+
+func TestWritePBM(t *testing.T) {
+	b := bytes.Buffer{}
+	if err := exampleRobot(t).WritePBM(&b); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "P1\n3 3\n0 0 1\n0 0 1\n1 1 0\n"; b.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, b.String())
+	}
+}
+
+func TestImage(t *testing.T) {
+	img := exampleRobot(t).Image()
+	if w, h := img.Bounds().Dx(), img.Bounds().Dy(); w != 3 || h != 3 {
+		t.Fatalf("expected 3x3, got %dx%d", w, h)
+	}
+	for _, tc := range []struct {
+		x, y     int
+		expected color.Gray
+	}{
+		{x: 2, y: 0, expected: color.Gray{Y: 255}},
+		{x: 0, y: 2, expected: color.Gray{Y: 255}},
+		{x: 0, y: 0, expected: color.Gray{Y: 0}},
+		{x: 2, y: 2, expected: color.Gray{Y: 0}},
+	} {
+		if c := img.At(tc.x, tc.y); c != tc.expected {
+			t.Fatalf("%d,%d: expected %v, got %v", tc.x, tc.y, tc.expected, c)
+		}
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	r := exampleRobot(t)
+	b := bytes.Buffer{}
+	if err := r.WritePNG(&b); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := r.Image()
+	if img.Bounds() != expected.Bounds() {
+		t.Fatalf("expected bounds %v, got %v", expected.Bounds(), img.Bounds())
+	}
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			if a, b := color.GrayModel.Convert(img.At(x, y)), expected.At(x, y); a != b {
+				t.Fatalf("%d,%d: expected %v, got %v", x, y, b, a)
+			}
+		}
+	}
+}
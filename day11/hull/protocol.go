@@ -0,0 +1,66 @@
+package hull
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xorkevin/advent2019/grid"
+)
+
+var (
+	ErrInvalidColor = errors.New("invalid color")
+	ErrInvalidTurn  = errors.New("invalid turn")
+)
+
+type (
+	// Protocol interprets the pair of values a painting program outputs on
+	// each step: the color to paint and how to turn afterwards
+	Protocol interface {
+		Colors() int
+		Color(v int) (int, error)
+		Turn(d grid.Dir, v int) (grid.Dir, error)
+	}
+
+	// TurnTable is a Protocol where each turn value rotates the robot by a
+	// fixed number of clockwise quarter turns
+	TurnTable struct {
+		NumColors int
+		Turns     map[int]int
+	}
+)
+
+const (
+	ColorBlack = 0
+	ColorWhite = 1
+)
+
+var (
+	// Standard is the protocol of the emergency hull painting robot: two
+	// colors, 0 turns left, and 1 turns right
+	Standard Protocol = TurnTable{
+		NumColors: 2,
+		Turns: map[int]int{
+			0: -1,
+			1: 1,
+		},
+	}
+)
+
+func (t TurnTable) Colors() int {
+	return t.NumColors
+}
+
+func (t TurnTable) Color(v int) (int, error) {
+	if v < 0 || v >= t.NumColors {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidColor, v)
+	}
+	return v, nil
+}
+
+func (t TurnTable) Turn(d grid.Dir, v int) (grid.Dir, error) {
+	q, ok := t.Turns[v]
+	if !ok {
+		return d, fmt.Errorf("%w: %d", ErrInvalidTurn, v)
+	}
+	return d.Rotate(q), nil
+}
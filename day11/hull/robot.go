@@ -0,0 +1,167 @@
+package hull
+
+import (
+	"errors"
+
+	"github.com/xorkevin/advent2019/grid"
+	"github.com/xorkevin/advent2019/ocr"
+)
+
+var (
+	ErrMissingTurn = errors.New("program painted without turning")
+)
+
+type (
+	// PaintEvent records a single step of the robot: the panel it painted,
+	// the color it was before and after, and the direction it then faced
+	PaintEvent struct {
+		Step  int
+		Pos   grid.Point
+		Prev  int
+		Color int
+		Dir   grid.Dir
+	}
+
+	// IO is the machine side of the robot, usually an intcode machine running
+	// the painting program
+	IO interface {
+		Write(v int)
+		Read() (int, bool)
+	}
+
+	Robot struct {
+		pos     grid.Point
+		dir     grid.Dir
+		proto   Protocol
+		board   *grid.Sparse
+		painted map[grid.Point]struct{}
+		history []PaintEvent
+	}
+)
+
+func NewRobot(proto Protocol) *Robot {
+	return &Robot{
+		pos:     grid.Point{},
+		dir:     grid.Up,
+		proto:   proto,
+		board:   grid.NewSparse(),
+		painted: map[grid.Point]struct{}{},
+		history: []PaintEvent{},
+	}
+}
+
+func (r *Robot) Pos() grid.Point {
+	return r.pos
+}
+
+func (r *Robot) Dir() grid.Dir {
+	return r.dir
+}
+
+func (r *Robot) ColorAt(p grid.Point) int {
+	return r.board.Get(p, ColorBlack)
+}
+
+func (r *Robot) Color() int {
+	return r.ColorAt(r.pos)
+}
+
+// Paint sets the color of the current panel without moving, for seeding the
+// starting panel. A seeded panel does not count as painted.
+func (r *Robot) Paint(color int) error {
+	c, err := r.proto.Color(color)
+	if err != nil {
+		return err
+	}
+	r.board.Set(r.pos, c)
+	return nil
+}
+
+// Step paints the current panel, turns, and moves forward one panel
+func (r *Robot) Step(color, turn int) error {
+	c, err := r.proto.Color(color)
+	if err != nil {
+		return err
+	}
+	dir, err := r.proto.Turn(r.dir, turn)
+	if err != nil {
+		return err
+	}
+	r.history = append(r.history, PaintEvent{
+		Step:  len(r.history),
+		Pos:   r.pos,
+		Prev:  r.Color(),
+		Color: c,
+		Dir:   dir,
+	})
+	r.board.Set(r.pos, c)
+	r.painted[r.pos] = struct{}{}
+	r.dir = dir
+	r.pos = r.pos.Step(dir, 1)
+	return nil
+}
+
+// Run drives the robot from the program until the program stops producing
+// output
+func (r *Robot) Run(m IO) error {
+	for {
+		m.Write(r.Color())
+		color, ok := m.Read()
+		if !ok {
+			return nil
+		}
+		turn, ok := m.Read()
+		if !ok {
+			return ErrMissingTurn
+		}
+		if err := r.Step(color, turn); err != nil {
+			return err
+		}
+	}
+}
+
+// Painted returns the number of panels painted by the program at least once
+func (r *Robot) Painted() int {
+	return len(r.painted)
+}
+
+func (r *Robot) History() []PaintEvent {
+	return r.history
+}
+
+// Bounds returns the bounding box of the painted and seeded area, and false
+// if nothing has been painted
+func (r *Robot) Bounds() (grid.Rect, bool) {
+	return r.board.Bounds()
+}
+
+// Grid returns the painted area as rows of colors
+func (r *Robot) Grid() [][]int {
+	bounds, ok := r.Bounds()
+	if !ok {
+		return nil
+	}
+	rows := make([][]int, 0, bounds.Height())
+	for y := bounds.Min.Y; y <= bounds.Max.Y; y++ {
+		row := make([]int, 0, bounds.Width())
+		for x := bounds.Min.X; x <= bounds.Max.X; x++ {
+			row = append(row, r.ColorAt(grid.Point{X: x, Y: y}))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// Identifier reads the block letters painted on the hull
+func (r *Robot) Identifier() (string, error) {
+	rows := r.Grid()
+	bits := make([][]bool, 0, len(rows))
+	for _, i := range rows {
+		row := make([]bool, 0, len(i))
+		for _, j := range i {
+			row = append(row, j != ColorBlack)
+		}
+		bits = append(bits, row)
+	}
+	return ocr.Parse(bits)
+}
@@ -0,0 +1,87 @@
+package hull
+
+import (
+	"testing"
+
+	"github.com/xorkevin/advent2019/grid"
+)
+
+type (
+	// script replays fixed program outputs, recording the colors the robot
+	// reports
+	script struct {
+		out  []int
+		seen []int
+	}
+)
+
+func (s *script) Write(v int) {
+	s.seen = append(s.seen, v)
+}
+
+func (s *script) Read() (int, bool) {
+	if len(s.out) == 0 {
+		return 0, false
+	}
+	v := s.out[0]
+	s.out = s.out[1:]
+	return v, true
+}
+
+func TestRobotExample(t *testing.T) {
+	r := NewRobot(Standard)
+	s := &script{
+		out: []int{1, 0, 0, 0, 1, 0, 1, 0, 0, 1, 1, 0, 1, 0},
+	}
+	if err := r.Run(s); err != nil {
+		t.Fatal(err)
+	}
+	if n := r.Painted(); n != 6 {
+		t.Fatalf("expected 6 panels painted, got %d", n)
+	}
+	if p, d := r.Pos(), r.Dir(); p != (grid.Point{X: 0, Y: -1}) || d != grid.Left {
+		t.Fatalf("expected robot at 0,-1 facing left, got %v facing %s", p, d)
+	}
+	// the robot returns to the first panel it painted white
+	if s.seen[4] != ColorWhite {
+		t.Fatalf("expected white on the revisited panel, got %d", s.seen[4])
+	}
+}
+
+func TestPaintSeed(t *testing.T) {
+	r := NewRobot(Standard)
+	if err := r.Paint(ColorWhite); err != nil {
+		t.Fatal(err)
+	}
+	if n := r.Painted(); n != 0 {
+		t.Fatalf("expected the seeded panel not to count as painted, got %d", n)
+	}
+	s := &script{
+		out: []int{0, 1, 1, 1, 1, 1, 1, 1},
+	}
+	if err := r.Run(s); err != nil {
+		t.Fatal(err)
+	}
+	if s.seen[0] != ColorWhite {
+		t.Fatalf("expected white on the seeded panel, got %d", s.seen[0])
+	}
+	// the robot circles back and paints the origin again
+	if n := r.Painted(); n != 4 {
+		t.Fatalf("expected 4 panels painted, got %d", n)
+	}
+	if err := r.Paint(3); err == nil {
+		t.Fatal("invalid color accepted")
+	}
+}
+
+func TestRobotInvalid(t *testing.T) {
+	if err := NewRobot(Standard).Run(&script{out: []int{1}}); err != ErrMissingTurn {
+		t.Fatalf("expected %v, got %v", ErrMissingTurn, err)
+	}
+	if err := NewRobot(Standard).Run(&script{out: []int{2, 0}}); err == nil {
+		t.Fatal("invalid color accepted")
+	}
+	if err := NewRobot(Standard).Run(&script{out: []int{0, 2}}); err == nil {
+		t.Fatal("invalid turn accepted")
+	}
+}
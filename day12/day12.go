@@ -0,0 +1,309 @@
+package day12
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+var (
+	ErrNoMoons      = errors.New("system has no moons")
+	ErrNoDimensions = errors.New("moons have no dimensions")
+	ErrDimensions   = errors.New("moons have mismatched dimensions")
+	ErrParse        = errors.New("invalid moon position")
+)
+
+type (
+	// System is a set of moons in any number of dimensions. Each axis is
+	// independent of the others, and every step can be undone, so each axis
+	// returns to its initial state with a period of its own.
+	System struct {
+		dims  int
+		steps int
+		pos   [][]int
+		vel   [][]int
+		init  Snapshot
+	}
+
+	Snapshot struct {
+		Steps int
+		Pos   [][]int
+		Vel   [][]int
+	}
+)
+
+func copyGrid(a [][]int) [][]int {
+	b := make([][]int, 0, len(a))
+	for _, i := range a {
+		row := make([]int, len(i))
+		copy(row, i)
+		b = append(b, row)
+	}
+	return b
+}
+
+func NewSystem(positions [][]int) (*System, error) {
+	if len(positions) == 0 {
+		return nil, ErrNoMoons
+	}
+	dims := len(positions[0])
+	if dims == 0 {
+		return nil, ErrNoDimensions
+	}
+	vel := make([][]int, 0, len(positions))
+	for _, i := range positions {
+		if len(i) != dims {
+			return nil, fmt.Errorf("%w: %d and %d", ErrDimensions, dims, len(i))
+		}
+		vel = append(vel, make([]int, dims))
+	}
+	s := &System{
+		dims:  dims,
+		steps: 0,
+		pos:   copyGrid(positions),
+		vel:   vel,
+	}
+	s.init = s.Snapshot()
+	return s, nil
+}
+
+func gravity(x, ox int) int {
+	if x < ox {
+		return +1
+	}
+	if x > ox {
+		return -1
+	}
+	return 0
+}
+
+func (s *System) applyGravity(sign int) {
+	for n, i := range s.pos {
+		for n2, j := range s.pos {
+			if n == n2 {
+				continue
+			}
+			for d := 0; d < s.dims; d++ {
+				s.vel[n][d] += sign * gravity(i[d], j[d])
+			}
+		}
+	}
+}
+
+func (s *System) applyVelocity(sign int) {
+	for n, i := range s.pos {
+		for d := range i {
+			i[d] += sign * s.vel[n][d]
+		}
+	}
+}
+
+func (s *System) Step() {
+	s.applyGravity(1)
+	s.applyVelocity(1)
+	s.steps++
+}
+
+// StepBack undoes a single step
+func (s *System) StepBack() {
+	s.applyVelocity(-1)
+	s.applyGravity(-1)
+	s.steps--
+}
+
+func (s *System) Steps() int {
+	return s.steps
+}
+
+func (s *System) Snapshot() Snapshot {
+	return Snapshot{
+		Steps: s.steps,
+		Pos:   copyGrid(s.pos),
+		Vel:   copyGrid(s.vel),
+	}
+}
+
+func (s *System) Restore(snap Snapshot) {
+	s.steps = snap.Steps
+	s.pos = copyGrid(snap.Pos)
+	s.vel = copyGrid(snap.Vel)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func (s *System) MoonEnergy(moon int) int {
+	e1 := 0
+	e2 := 0
+	for d := 0; d < s.dims; d++ {
+		e1 += abs(s.pos[moon][d])
+		e2 += abs(s.vel[moon][d])
+	}
+	return e1 * e2
+}
+
+func (s *System) Energy() int {
+	count := 0
+	for n := range s.pos {
+		count += s.MoonEnergy(n)
+	}
+	return count
+}
+
+// AxisPeriod simulates a single axis from the initial state until it returns
+// there. Since every state has exactly one predecessor, the first repeated
+// state must be the initial one, so no history of states is needed.
+func (s *System) AxisPeriod(axis int) int {
+	n := len(s.init.Pos)
+	initPos := make([]int, n)
+	initVel := make([]int, n)
+	for i := 0; i < n; i++ {
+		initPos[i] = s.init.Pos[i][axis]
+		initVel[i] = s.init.Vel[i][axis]
+	}
+	pos := make([]int, n)
+	vel := make([]int, n)
+	copy(pos, initPos)
+	copy(vel, initVel)
+	for steps := 1; ; steps++ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				vel[i] += gravity(pos[i], pos[j])
+			}
+		}
+		match := true
+		for i := 0; i < n; i++ {
+			pos[i] += vel[i]
+			if pos[i] != initPos[i] || vel[i] != initVel[i] {
+				match = false
+			}
+		}
+		if match {
+			return steps
+		}
+	}
+}
+
+// Period is the number of steps for the whole system to return to its
+// initial state
+func (s *System) Period() int {
+	result := s.AxisPeriod(0)
+	for d := 1; d < s.dims; d++ {
+		result = LCM(result, s.AxisPeriod(d))
+	}
+	return result
+}
+
+// WriteEnergyCSV steps the system forward, writing the energy of each moon
+// and the total energy before the first step and after every step
+func (s *System) WriteEnergyCSV(w io.Writer, steps int) error {
+	b := bufio.NewWriter(w)
+	b.WriteString("step")
+	for n := range s.pos {
+		fmt.Fprintf(b, ",moon%d", n)
+	}
+	b.WriteString(",total\n")
+	for i := 0; ; i++ {
+		b.WriteString(strconv.Itoa(s.steps))
+		for n := range s.pos {
+			b.WriteByte(',')
+			b.WriteString(strconv.Itoa(s.MoonEnergy(n)))
+		}
+		b.WriteByte(',')
+		b.WriteString(strconv.Itoa(s.Energy()))
+		b.WriteByte('\n')
+		if i == steps {
+			break
+		}
+		s.Step()
+	}
+	return b.Flush()
+}
+
+// parseLine reads a position written as <x=1, y=2, z=3>
+func parseLine(line parse.Field) ([]int, error) {
+	fields := line.TrimSpace().Trim("<", ">").Split(",")
+	vec := make([]int, 0, len(fields))
+	for _, i := range fields {
+		k := strings.IndexByte(i.Text, '=')
+		if k < 0 {
+			i = i.TrimSpace()
+			return nil, i.Errorf("%w: expected an axis=value, found %q", ErrParse, i.Text)
+		}
+		num, err := i.Sub(k+1, len(i.Text)).Int()
+		if err != nil {
+			return nil, err
+		}
+		vec = append(vec, num)
+	}
+	return vec, nil
+}
+
+func GCD(a, b int) int {
+	for b != 0 {
+		t := b
+		b = a % b
+		a = t
+	}
+	return a
+}
+
+// find Least Common Multiple (LCM) via GCD
+func LCM(a, b int, integers ...int) int {
+	result := a * b / GCD(a, b)
+
+	for i := 0; i < len(integers); i++ {
+		result = LCM(result, integers[i])
+	}
+
+	return result
+}
+
+// Parse reads a system from one moon position per line
+func Parse(r io.Reader) (*System, error) {
+	lines, err := parse.NonBlank(r)
+	if err != nil {
+		return nil, err
+	}
+	positions := make([][]int, 0, len(lines))
+	for _, line := range lines {
+		pos, err := parseLine(line)
+		if err != nil {
+			return nil, err
+		}
+		if len(positions) > 0 && len(pos) != len(positions[0]) {
+			return nil, line.Errorf("%w: %d and %d", ErrDimensions, len(positions[0]), len(pos))
+		}
+		positions = append(positions, pos)
+	}
+	return NewSystem(positions)
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	sys, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < 1000; i++ {
+		sys.Step()
+	}
+	return aoc.Int(sys.Energy()), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	sys, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(sys.Period()), nil
+}
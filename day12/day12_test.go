@@ -0,0 +1,114 @@
+package day12
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func TestSystem(t *testing.T) {
+	for _, tc := range []struct {
+		file   string
+		steps  int
+		energy int
+		period int
+	}{
+		{file: "example1.txt", steps: 10, energy: 179, period: 2772},
+		{file: "example2.txt", steps: 100, energy: 1940, period: 4686774924},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			sys, err := Parse(testutil.ReadFile(t, tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			start := sys.Snapshot()
+			for i := 0; i < tc.steps; i++ {
+				sys.Step()
+			}
+			if e := sys.Energy(); e != tc.energy {
+				t.Errorf("expected energy %d after %d steps, got %d", tc.energy, tc.steps, e)
+			}
+			for i := 0; i < tc.steps; i++ {
+				sys.StepBack()
+			}
+			if s := sys.Snapshot(); !reflect.DeepEqual(s.Pos, start.Pos) || !reflect.DeepEqual(s.Vel, start.Vel) {
+				t.Errorf("stepping back did not restore the initial state")
+			}
+			if p := sys.Period(); p != tc.period {
+				t.Errorf("expected period %d, got %d", tc.period, p)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	if _, err := Parse(strings.NewReader("<x=1, y=2>\n<x=1, y=2, z=3>\n")); !errors.Is(err, ErrDimensions) {
+		t.Errorf("mismatched dimensions: %v", err)
+	}
+	if _, err := Parse(strings.NewReader("<x=1, y>\n")); !errors.Is(err, ErrParse) {
+		t.Errorf("invalid position: %v", err)
+	}
+	if _, err := Parse(strings.NewReader("")); !errors.Is(err, ErrNoMoons) {
+		t.Errorf("no moons: %v", err)
+	}
+}
+
+func TestNewSystemInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		positions [][]int
+		err       error
+	}{
+		{name: "no moons", positions: nil, err: ErrNoMoons},
+		{name: "no dimensions", positions: [][]int{{}, {}}, err: ErrNoDimensions},
+		{name: "mismatched", positions: [][]int{{1, 2}, {1}}, err: ErrDimensions},
+		{name: "mismatched after empty", positions: [][]int{{}, {1}}, err: ErrNoDimensions},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewSystem(tc.positions); !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestWriteEnergyCSV(t *testing.T) {
+	sys, err := Parse(testutil.ReadFile(t, "example1.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := bytes.Buffer{}
+	if err := sys.WriteEnergyCSV(&b, 10); err != nil {
+		t.Fatal(err)
+	}
+	expected, err := ioutil.ReadFile(filepath.Join("testdata", "energy1.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(expected) {
+		t.Fatalf("expected %q, got %q", expected, b.String())
+	}
+	if sys.Steps() != 10 {
+		t.Fatalf("expected the system to be left at step 10, got %d", sys.Steps())
+	}
+}
+
+func TestFuzzParse(t *testing.T) {
+	tokens := []string{"<", ">", "x=", "y=", "z=", "=", "-", "7", "12", ",", ", ", " ", "\n"}
+	if err := testutil.Fuzz(tokens, func(r io.Reader) error {
+		_, err := Parse(r)
+		if errors.Is(err, ErrNoMoons) {
+			return nil
+		}
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
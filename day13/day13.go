@@ -0,0 +1,156 @@
+package day13
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/intcode"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+const (
+	ramSize = 8192
+)
+
+var (
+	ErrMissingY    = errors.New("failed to read y")
+	ErrMissingTile = errors.New("failed to read tile")
+)
+
+const (
+	tileEmpty  = 0
+	tileWall   = 1
+	tileBlock  = 2
+	tilePaddle = 3
+	tileBall   = 4
+)
+
+type (
+	Point struct {
+		x, y int
+	}
+
+	Board struct {
+		grid map[Point]int
+	}
+)
+
+func NewBoard() *Board {
+	return &Board{
+		grid: map[Point]int{},
+	}
+}
+
+func (b *Board) Emplace(p Point, tile int) {
+	b.grid[p] = tile
+}
+
+func (b *Board) BlockCount() int {
+	count := 0
+	for _, v := range b.grid {
+		if v == tileBlock {
+			count++
+		}
+	}
+	return count
+}
+
+func (b *Board) Print() {
+	maxX := 0
+	maxY := 0
+	for k, _ := range b.grid {
+		if k.x > maxX {
+			maxX = k.x
+		}
+		if k.y > maxY {
+			maxY = k.y
+		}
+	}
+
+	for i := 0; i <= maxY; i++ {
+		for j := 0; j <= maxX; j++ {
+			v, ok := b.grid[Point{j, i}]
+			if !ok {
+				fmt.Fprint(os.Stderr, " ")
+				continue
+			}
+			switch v {
+			case tileEmpty:
+				fmt.Fprint(os.Stderr, " ")
+			case tileWall:
+				fmt.Fprint(os.Stderr, "#")
+			case tileBlock:
+				fmt.Fprint(os.Stderr, "+")
+			case tilePaddle:
+				fmt.Fprint(os.Stderr, "_")
+			case tileBall:
+				fmt.Fprint(os.Stderr, "O")
+			}
+		}
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// play runs the game, passing every tile it draws to draw
+func play(r io.Reader, quarters int, draw func(x, y, tile int)) error {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return err
+	}
+	mem := make([]int, ramSize)
+	copy(mem, tokens)
+	if quarters > 0 {
+		mem[0] = quarters
+	}
+	m := intcode.NewMachine(mem)
+	m.SetInput(func() int { return 0 })
+	for {
+		x, ok := m.Read()
+		if !ok {
+			return m.Err()
+		}
+		y, ok := m.Read()
+		if !ok {
+			if err := m.Err(); err != nil {
+				return err
+			}
+			return ErrMissingY
+		}
+		tile, ok := m.Read()
+		if !ok {
+			if err := m.Err(); err != nil {
+				return err
+			}
+			return ErrMissingTile
+		}
+		draw(x, y, tile)
+	}
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	b := NewBoard()
+	if err := play(r, 0, func(x, y, tile int) {
+		b.Emplace(Point{x, y}, tile)
+	}); err != nil {
+		return nil, err
+	}
+	return aoc.Int(b.BlockCount()), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	b := NewBoard()
+	score := 0
+	if err := play(r, 2, func(x, y, tile int) {
+		if x == -1 && y == 0 {
+			score = tile
+		} else {
+			b.Emplace(Point{x, y}, tile)
+		}
+	}); err != nil {
+		return nil, err
+	}
+	return aoc.Int(score), nil
+}
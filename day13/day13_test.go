@@ -0,0 +1,32 @@
+package day13
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/intcode"
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func TestAnswers(t *testing.T) {
+	testutil.CheckAnswers(t, Part1, Part2)
+}
+
+func TestErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		prog     string
+		expected error
+	}{
+		{name: "missing y", prog: "104,1,99", expected: ErrMissingY},
+		{name: "missing tile", prog: "104,1,104,2,99", expected: ErrMissingTile},
+		{name: "illegal op", prog: "104,1,104,2", expected: intcode.ErrIllegalOp},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Part1(strings.NewReader(tc.prog)); !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package day14
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day14/nanofactory"
+)
+
+const (
+	trillion = 1000000000000
+)
+
+func parseFactory(r io.Reader) (*nanofactory.Factory, error) {
+	reactions, err := nanofactory.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return nanofactory.NewFactory(reactions)
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	f, err := parseFactory(r)
+	if err != nil {
+		return nil, err
+	}
+	ore, err := f.Cost(nanofactory.Fuel, 1, nanofactory.Ore)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(ore), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	f, err := parseFactory(r)
+	if err != nil {
+		return nil, err
+	}
+	fuel, err := f.MaxFuel(trillion)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(fuel), nil
+}
@@ -0,0 +1,77 @@
+package day14
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day14/nanofactory"
+	"github.com/xorkevin/advent2019/internal/testutil"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func TestOre(t *testing.T) {
+	for _, tc := range []struct {
+		file string
+		ore  int
+		fuel int
+	}{
+		{file: "example1.txt", ore: 31},
+		{file: "example2.txt", ore: 165},
+		{file: "example3.txt", ore: 13312, fuel: 82892753},
+		{file: "example4.txt", ore: 180697, fuel: 5586022},
+		{file: "example5.txt", ore: 2210736, fuel: 460664},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			a, err := Part1(testutil.ReadFile(t, tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a != aoc.Int(tc.ore) {
+				t.Errorf("expected %d ore, got %v", tc.ore, a)
+			}
+			if tc.fuel == 0 {
+				return
+			}
+			a, err = Part2(testutil.ReadFile(t, tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a != aoc.Int(tc.fuel) {
+				t.Errorf("expected %d fuel, got %v", tc.fuel, a)
+			}
+		})
+	}
+}
+
+func TestFactoryInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		input    string
+		expected error
+	}{
+		{name: "cycle", input: "1 A => 1 B\n1 B => 1 A\n1 A => 1 FUEL\n", expected: nanofactory.ErrCycle},
+		{name: "duplicate", input: "1 ORE => 1 A\n2 ORE => 1 A\n1 A => 1 FUEL\n", expected: nanofactory.ErrDuplicate},
+		{name: "separator", input: "1 ORE -> 1 FUEL\n", expected: parse.ErrSep},
+		{name: "quantity", input: "1 ORE => 1 FUEL X\n", expected: nanofactory.ErrParse},
+		{name: "count", input: "0 ORE => 1 FUEL\n", expected: nanofactory.ErrParse},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Part1(strings.NewReader(tc.input)); !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestFuzzParse(t *testing.T) {
+	tokens := []string{"7", "0", "-1", " ", "ORE", "FUEL", "A", ",", ", ", "=>", " => ", "\n"}
+	if err := testutil.Fuzz(tokens, func(r io.Reader) error {
+		_, err := nanofactory.Parse(r)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
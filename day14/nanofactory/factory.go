@@ -0,0 +1,201 @@
+package nanofactory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrDuplicate   = errors.New("chemical is produced by more than one reaction")
+	ErrCycle       = errors.New("reactions form a cycle")
+	ErrUnreachable = errors.New("chemical cannot be produced from base resources")
+	ErrBase        = errors.New("base resource is produced by a reaction")
+)
+
+const (
+	Ore  = "ORE"
+	Fuel = "FUEL"
+)
+
+type (
+	// Factory is a validated set of reactions, where every chemical is either
+	// a base resource or produced by exactly one reaction
+	Factory struct {
+		reactions map[string]Reaction
+		bases     map[string]struct{}
+		order     []string
+	}
+)
+
+// NewFactory validates the reactions against the base resources, which
+// default to ORE
+func NewFactory(reactions []Reaction, bases ...string) (*Factory, error) {
+	if len(bases) == 0 {
+		bases = []string{Ore}
+	}
+	f := &Factory{
+		reactions: make(map[string]Reaction, len(reactions)),
+		bases:     make(map[string]struct{}, len(bases)),
+	}
+	for _, i := range bases {
+		f.bases[i] = struct{}{}
+	}
+	for _, i := range reactions {
+		if _, ok := f.bases[i.Out.Chem]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrBase, i.Out.Chem)
+		}
+		if _, ok := f.reactions[i.Out.Chem]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicate, i.Out.Chem)
+		}
+		f.reactions[i.Out.Chem] = i
+	}
+	for _, i := range reactions {
+		for _, j := range i.In {
+			if !f.known(j.Chem) {
+				return nil, fmt.Errorf("%w: %s needed by %s", ErrUnreachable, j.Chem, i.Out.Chem)
+			}
+		}
+	}
+	order, err := f.topoSort()
+	if err != nil {
+		return nil, err
+	}
+	f.order = order
+	return f, nil
+}
+
+func (f *Factory) known(chem string) bool {
+	if _, ok := f.bases[chem]; ok {
+		return true
+	}
+	_, ok := f.reactions[chem]
+	return ok
+}
+
+func (f *Factory) IsBase(chem string) bool {
+	_, ok := f.bases[chem]
+	return ok
+}
+
+func (f *Factory) Reaction(chem string) (Reaction, bool) {
+	re, ok := f.reactions[chem]
+	return re, ok
+}
+
+// topoSort orders produced chemicals so that every chemical comes before all
+// of the chemicals it is made from
+func (f *Factory) topoSort() ([]string, error) {
+	consumers := make(map[string]int, len(f.reactions))
+	for _, i := range f.reactions {
+		for _, j := range i.In {
+			consumers[j.Chem]++
+		}
+	}
+	ready := []string{}
+	for k := range f.reactions {
+		if consumers[k] == 0 {
+			ready = append(ready, k)
+		}
+	}
+	sort.Strings(ready)
+	order := make([]string, 0, len(f.reactions))
+	for len(ready) > 0 {
+		chem := ready[0]
+		ready = ready[1:]
+		order = append(order, chem)
+		for _, i := range f.reactions[chem].In {
+			if f.IsBase(i.Chem) {
+				continue
+			}
+			consumers[i.Chem]--
+			if consumers[i.Chem] == 0 {
+				ready = append(ready, i.Chem)
+			}
+		}
+	}
+	if len(order) != len(f.reactions) {
+		cycle := []string{}
+		for k := range f.reactions {
+			if consumers[k] > 0 {
+				cycle = append(cycle, k)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("%w among %v", ErrCycle, cycle)
+	}
+	return order, nil
+}
+
+// Require returns the amount of each base resource consumed to produce the
+// targets
+func (f *Factory) Require(targets ...Quantity) (map[string]int, error) {
+	p, err := f.Plan(targets...)
+	if err != nil {
+		return nil, err
+	}
+	return p.Base, nil
+}
+
+// Cost returns the amount of a single base resource needed to produce amt of
+// chem
+func (f *Factory) Cost(chem string, amt int, base string) (int, error) {
+	if !f.IsBase(base) {
+		return 0, fmt.Errorf("%w: %s is not a base resource", ErrUnreachable, base)
+	}
+	req, err := f.Require(Quantity{
+		Chem:  chem,
+		Count: amt,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return req[base], nil
+}
+
+// MaxProduce returns the largest amount of chem that can be produced within
+// the budget of a base resource, by binary search between the amount that
+// batch leftovers guarantee and the first doubling that exceeds the budget
+func (f *Factory) MaxProduce(chem string, base string, budget int) (int, error) {
+	unit, err := f.Cost(chem, 1, base)
+	if err != nil {
+		return 0, err
+	}
+	if unit > budget {
+		return 0, nil
+	}
+	if unit == 0 {
+		return 0, fmt.Errorf("%w: %s does not use %s", ErrUnreachable, chem, base)
+	}
+	lo := budget / unit
+	hi := lo * 2
+	for {
+		k, err := f.Cost(chem, hi, base)
+		if err != nil {
+			return 0, err
+		}
+		if k > budget {
+			break
+		}
+		lo = hi
+		hi *= 2
+	}
+	for lo+1 < hi {
+		mid := lo + (hi-lo)/2
+		k, err := f.Cost(chem, mid, base)
+		if err != nil {
+			return 0, err
+		}
+		if k <= budget {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// MaxFuel returns the most fuel that can be produced from the ore budget
+func (f *Factory) MaxFuel(oreBudget int) (int, error) {
+	return f.MaxProduce(Fuel, Ore, oreBudget)
+}
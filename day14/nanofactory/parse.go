@@ -0,0 +1,82 @@
+package nanofactory
+
+import (
+	"errors"
+	"io"
+
+	"github.com/xorkevin/advent2019/parse"
+)
+
+var (
+	ErrParse = errors.New("invalid reaction")
+)
+
+type (
+	Quantity struct {
+		Chem  string
+		Count int
+	}
+
+	Reaction struct {
+		Out Quantity
+		In  []Quantity
+	}
+)
+
+// ParseQuantity parses a count and a chemical, such as "7 A"
+func ParseQuantity(f parse.Field) (Quantity, error) {
+	l := f.Words()
+	if len(l) != 2 {
+		f = f.TrimSpace()
+		return Quantity{}, f.Errorf("%w: quantity %q is not a count and a chemical", ErrParse, f.Text)
+	}
+	num, err := l[0].Int()
+	if err != nil {
+		return Quantity{}, err
+	}
+	if num <= 0 {
+		return Quantity{}, l[0].Errorf("%w: quantity %d is not positive", ErrParse, num)
+	}
+	return Quantity{
+		Chem:  l[1].Text,
+		Count: num,
+	}, nil
+}
+
+// ParseReaction parses a reaction of the form "7 A, 1 B => 1 C"
+func ParseReaction(p parse.Pair) (Reaction, error) {
+	out, err := ParseQuantity(p.Right)
+	if err != nil {
+		return Reaction{}, err
+	}
+	inpS := p.Left.Split(",")
+	inp := make([]Quantity, 0, len(inpS))
+	for _, i := range inpS {
+		q, err := ParseQuantity(i)
+		if err != nil {
+			return Reaction{}, err
+		}
+		inp = append(inp, q)
+	}
+	return Reaction{
+		Out: out,
+		In:  inp,
+	}, nil
+}
+
+// Parse reads one reaction per line, skipping blank lines
+func Parse(r io.Reader) ([]Reaction, error) {
+	pairs, err := parse.Pairs(r, "=>")
+	if err != nil {
+		return nil, err
+	}
+	reactions := make([]Reaction, 0, len(pairs))
+	for _, i := range pairs {
+		re, err := ParseReaction(i)
+		if err != nil {
+			return nil, err
+		}
+		reactions = append(reactions, re)
+	}
+	return reactions, nil
+}
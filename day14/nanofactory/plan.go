@@ -0,0 +1,216 @@
+package nanofactory
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+)
+
+type (
+	// Step is how a single chemical is made in a plan. Cost is the amount of
+	// each base resource spent on every unit produced, and Waste is the part
+	// of that spent on units left over.
+	Step struct {
+		Chem     string
+		Runs     int
+		Produced int
+		Consumed int
+		Leftover int
+		Cost     map[string]float64
+		Waste    map[string]float64
+	}
+
+	// Plan is the production plan for a set of targets, with steps ordered
+	// so that every chemical is made after its ingredients
+	Plan struct {
+		Targets []Quantity
+		Steps   []Step
+		Base    map[string]int
+		f       *Factory
+		index   map[string]int
+	}
+)
+
+// Plan computes how many times each reaction runs to produce the targets,
+// and traces how much of each base resource flows into each chemical
+func (f *Factory) Plan(targets ...Quantity) (*Plan, error) {
+	need := map[string]int{}
+	for _, i := range targets {
+		if !f.known(i.Chem) {
+			return nil, fmt.Errorf("%w: %s", ErrUnreachable, i.Chem)
+		}
+		need[i.Chem] += i.Count
+	}
+	runs := map[string]int{}
+	for _, chem := range f.order {
+		amt := need[chem]
+		if amt <= 0 {
+			continue
+		}
+		re := f.reactions[chem]
+		k := (amt + re.Out.Count - 1) / re.Out.Count
+		runs[chem] = k
+		for _, i := range re.In {
+			need[i.Chem] += i.Count * k
+		}
+	}
+
+	p := &Plan{
+		Targets: targets,
+		Steps:   make([]Step, 0, len(runs)+len(f.bases)),
+		Base:    make(map[string]int, len(f.bases)),
+		f:       f,
+		index:   map[string]int{},
+	}
+	bases := make([]string, 0, len(f.bases))
+	for k := range f.bases {
+		bases = append(bases, k)
+	}
+	sort.Strings(bases)
+	for _, k := range bases {
+		p.Base[k] = need[k]
+		if need[k] == 0 {
+			continue
+		}
+		p.add(Step{
+			Chem:     k,
+			Runs:     0,
+			Produced: need[k],
+			Consumed: need[k],
+			Leftover: 0,
+			Cost:     map[string]float64{k: float64(need[k])},
+			Waste:    map[string]float64{},
+		})
+	}
+	for n := len(f.order) - 1; n >= 0; n-- {
+		chem := f.order[n]
+		k, ok := runs[chem]
+		if !ok {
+			continue
+		}
+		re := f.reactions[chem]
+		s := Step{
+			Chem:     chem,
+			Runs:     k,
+			Produced: k * re.Out.Count,
+			Consumed: need[chem],
+			Leftover: k*re.Out.Count - need[chem],
+			Cost:     map[string]float64{},
+			Waste:    map[string]float64{},
+		}
+		for _, i := range re.In {
+			in := p.Steps[p.index[i.Chem]]
+			frac := float64(i.Count*k) / float64(in.Produced)
+			for b, c := range in.Cost {
+				s.Cost[b] += c * frac
+			}
+		}
+		for b, c := range s.Cost {
+			if s.Leftover > 0 {
+				s.Waste[b] = c * float64(s.Leftover) / float64(s.Produced)
+			}
+		}
+		p.add(s)
+	}
+	return p, nil
+}
+
+func (p *Plan) add(s Step) {
+	p.index[s.Chem] = len(p.Steps)
+	p.Steps = append(p.Steps, s)
+}
+
+// Step returns the step that makes a chemical
+func (p *Plan) Step(chem string) (Step, bool) {
+	n, ok := p.index[chem]
+	if !ok {
+		return Step{}, false
+	}
+	return p.Steps[n], true
+}
+
+// CriticalPath follows the ingredient that carries the most of the base
+// resource, from a chemical down to the base resource
+func (p *Plan) CriticalPath(chem string, base string) []string {
+	path := []string{}
+	for {
+		s, ok := p.Step(chem)
+		if !ok {
+			return path
+		}
+		path = append(path, chem)
+		re, ok := p.f.reactions[chem]
+		if !ok {
+			return path
+		}
+		next := ""
+		max := -1.0
+		for _, i := range re.In {
+			in, ok := p.Step(i.Chem)
+			if !ok {
+				continue
+			}
+			if c := in.Cost[base] * float64(i.Count*s.Runs) / float64(in.Produced); c > max {
+				next = i.Chem
+				max = c
+			}
+		}
+		if next == "" {
+			return path
+		}
+		chem = next
+	}
+}
+
+// WriteTable writes one row per step with its base resource cost and waste
+func (p *Plan) WriteTable(w io.Writer) error {
+	bases := make([]string, 0, len(p.Base))
+	for k := range p.Base {
+		bases = append(bases, k)
+	}
+	sort.Strings(bases)
+
+	t := tabwriter.NewWriter(w, 0, 4, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(t, "CHEM\tRUNS\tPRODUCED\tCONSUMED\tLEFTOVER\t")
+	for _, b := range bases {
+		fmt.Fprintf(t, "%s\t%s WASTE\t", b, b)
+	}
+	fmt.Fprintln(t)
+	for _, s := range p.Steps {
+		fmt.Fprintf(t, "%s\t%d\t%d\t%d\t%d\t", s.Chem, s.Runs, s.Produced, s.Consumed, s.Leftover)
+		for _, b := range bases {
+			fmt.Fprintf(t, "%.2f\t%.2f\t", s.Cost[b], s.Waste[b])
+		}
+		fmt.Fprintln(t)
+	}
+	return t.Flush()
+}
+
+// WriteDOT writes the reactions used by the plan as a Graphviz digraph, with
+// each edge weighted by the amount of the ingredient consumed
+func (p *Plan) WriteDOT(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	b.WriteString("digraph reactions {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, s := range p.Steps {
+		if p.f.IsBase(s.Chem) {
+			fmt.Fprintf(b, "  %q [shape=box, label=\"%s\\n%d\"];\n", s.Chem, s.Chem, s.Consumed)
+		} else {
+			fmt.Fprintf(b, "  %q [label=\"%s\\n%d x%d, %d left\"];\n", s.Chem, s.Chem, s.Produced, s.Runs, s.Leftover)
+		}
+	}
+	for _, s := range p.Steps {
+		re, ok := p.f.reactions[s.Chem]
+		if !ok {
+			continue
+		}
+		for _, i := range re.In {
+			k := i.Count * s.Runs
+			fmt.Fprintf(b, "  %q -> %q [label=\"%d\", weight=%d];\n", i.Chem, s.Chem, k, k)
+		}
+	}
+	b.WriteString("}\n")
+	return b.Flush()
+}
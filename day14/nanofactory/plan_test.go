@@ -0,0 +1,152 @@
+package nanofactory
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+// readFactory reads one of the examples in the testdata of day14
+func readFactory(t *testing.T, name string) *Factory {
+	t.Helper()
+	reactions, err := Parse(testutil.ReadFile(t, filepath.Join("..", "..", "testdata", name)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFactory(reactions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestPlan(t *testing.T) {
+	f := readFactory(t, "example1.txt")
+	for _, tc := range []struct {
+		name    string
+		targets []Quantity
+		ore     int
+		steps   map[string]Step
+	}{
+		{
+			name:    "one fuel",
+			targets: []Quantity{{Chem: Fuel, Count: 1}},
+			ore:     31,
+			steps: map[string]Step{
+				"A":  {Chem: "A", Runs: 3, Produced: 30, Consumed: 28, Leftover: 2, Cost: map[string]float64{Ore: 30}, Waste: map[string]float64{Ore: 2}},
+				"C":  {Chem: "C", Runs: 1, Produced: 1, Consumed: 1, Leftover: 0, Cost: map[string]float64{Ore: 8}, Waste: map[string]float64{}},
+				Fuel: {Chem: Fuel, Runs: 1, Produced: 1, Consumed: 1, Leftover: 0, Cost: map[string]float64{Ore: 29}, Waste: map[string]float64{}},
+				Ore:  {Chem: Ore, Runs: 0, Produced: 31, Consumed: 31, Leftover: 0, Cost: map[string]float64{Ore: 31}, Waste: map[string]float64{}},
+			},
+		},
+		{
+			name:    "batch",
+			targets: []Quantity{{Chem: Fuel, Count: 2}},
+			ore:     62,
+			steps: map[string]Step{
+				"A": {Chem: "A", Runs: 6, Produced: 60, Consumed: 56, Leftover: 4, Cost: map[string]float64{Ore: 60}, Waste: map[string]float64{Ore: 4}},
+				"B": {Chem: "B", Runs: 2, Produced: 2, Consumed: 2, Leftover: 0, Cost: map[string]float64{Ore: 2}, Waste: map[string]float64{}},
+			},
+		},
+		{
+			name:    "several targets",
+			targets: []Quantity{{Chem: Fuel, Count: 1}, {Chem: "C", Count: 1}},
+			ore:     42,
+			steps: map[string]Step{
+				"A": {Chem: "A", Runs: 4, Produced: 40, Consumed: 35, Leftover: 5, Cost: map[string]float64{Ore: 40}, Waste: map[string]float64{Ore: 5}},
+				"C": {Chem: "C", Runs: 2, Produced: 2, Consumed: 2, Leftover: 0, Cost: map[string]float64{Ore: 16}, Waste: map[string]float64{}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := f.Plan(tc.targets...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Base[Ore] != tc.ore {
+				t.Fatalf("expected %d ore, got %d", tc.ore, p.Base[Ore])
+			}
+			for k, v := range tc.steps {
+				s, ok := p.Step(k)
+				if !ok {
+					t.Fatalf("no step for %s", k)
+				}
+				if !reflect.DeepEqual(s, v) {
+					t.Errorf("expected %+v, got %+v", v, s)
+				}
+			}
+			// every chemical is made after its ingredients
+			for n, i := range p.Steps {
+				re, ok := f.Reaction(i.Chem)
+				if !ok {
+					continue
+				}
+				for _, j := range re.In {
+					if p.index[j.Chem] >= n {
+						t.Errorf("%s is made before its ingredient %s", i.Chem, j.Chem)
+					}
+				}
+			}
+		})
+	}
+	if _, err := f.Plan(Quantity{Chem: "X", Count: 1}); !errors.Is(err, ErrUnreachable) {
+		t.Fatalf("expected %v, got %v", ErrUnreachable, err)
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	f := readFactory(t, "example1.txt")
+	p, err := f.Plan(Quantity{Chem: Fuel, Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		chem     string
+		expected []string
+	}{
+		{chem: Fuel, expected: []string{Fuel, "E", "D", "C", "A", Ore}},
+		{chem: "C", expected: []string{"C", "A", Ore}},
+		{chem: Ore, expected: []string{Ore}},
+		{chem: "X", expected: []string{}},
+	} {
+		t.Run(tc.chem, func(t *testing.T) {
+			if path := p.CriticalPath(tc.chem, Ore); !reflect.DeepEqual(path, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, path)
+			}
+		})
+	}
+}
+
+func TestPlanOutput(t *testing.T) {
+	f := readFactory(t, "example1.txt")
+	p, err := f.Plan(Quantity{Chem: Fuel, Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		file  string
+		write func(b *bytes.Buffer) error
+	}{
+		{file: "plan1.txt", write: func(b *bytes.Buffer) error { return p.WriteTable(b) }},
+		{file: "plan1.dot", write: func(b *bytes.Buffer) error { return p.WriteDOT(b) }},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			b := bytes.Buffer{}
+			if err := tc.write(&b); err != nil {
+				t.Fatal(err)
+			}
+			expected, err := ioutil.ReadFile(filepath.Join("testdata", tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != string(expected) {
+				t.Fatalf("expected %q, got %q", expected, b.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package day15
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/grid"
+	"github.com/xorkevin/advent2019/intcode"
+	"github.com/xorkevin/advent2019/parse"
+	"github.com/xorkevin/advent2019/search"
+)
+
+const (
+	ramSize = 8192
+)
+
+var (
+	ErrNoOxygen = errors.New("no oxygen system")
+	ErrCrashed  = errors.New("bot crashed")
+	ErrStatus   = errors.New("illegal status")
+)
+
+const (
+	statusWall = 0
+	statusMove = 1
+	statusGoal = 2
+)
+
+type (
+	Bot struct {
+		pos   grid.Point
+		m     *intcode.Machine
+		tiles *grid.Sparse
+	}
+)
+
+func NewBot(m *intcode.Machine) *Bot {
+	tiles := grid.NewSparse()
+	tiles.Set(grid.Point{}, statusMove)
+	return &Bot{
+		pos:   grid.Point{},
+		m:     m,
+		tiles: tiles,
+	}
+}
+
+// command returns the movement command the droid accepts for a direction
+func command(dir grid.Dir) int {
+	switch dir {
+	case grid.Up:
+		return 1
+	case grid.Down:
+		return 2
+	case grid.Left:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func isOpen(k int) bool {
+	return k != statusWall
+}
+
+func (r *Bot) move(dir grid.Dir) (int, error) {
+	r.m.Write(command(dir))
+	k, ok := r.m.Read()
+	if !ok {
+		if err := r.m.Err(); err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrCrashed, err)
+		}
+		return 0, fmt.Errorf("%w: halted", ErrCrashed)
+	}
+	switch k {
+	case statusWall:
+	case statusMove, statusGoal:
+		r.pos = r.pos.Step(dir, 1)
+	default:
+		return 0, fmt.Errorf("%w: %d", ErrStatus, k)
+	}
+	return k, nil
+}
+
+// Explore maps every tile reachable from the bot by depth first search,
+// backtracking after each dead end
+func (r *Bot) Explore() error {
+	for _, dir := range grid.Dirs {
+		next := r.pos.Step(dir, 1)
+		if _, ok := r.tiles.At(next); ok {
+			continue
+		}
+		k, err := r.move(dir)
+		if err != nil {
+			return err
+		}
+		r.tiles.Set(next, k)
+		if k == statusWall {
+			continue
+		}
+		if err := r.Explore(); err != nil {
+			return err
+		}
+		k, err = r.move(dir.Reverse())
+		if err != nil {
+			return err
+		}
+		if k == statusWall {
+			return fmt.Errorf("%w: blocked on reverse", ErrCrashed)
+		}
+	}
+	return nil
+}
+
+func (r *Bot) Goal() (grid.Point, bool) {
+	goals := r.tiles.Find(statusGoal)
+	if len(goals) == 0 {
+		return grid.Point{}, false
+	}
+	return goals[0], true
+}
+
+func (r *Bot) Neighbors(n search.Node) []search.Edge {
+	next := r.tiles.Neighbors4(n.(grid.Point), isOpen)
+	edges := make([]search.Edge, 0, len(next))
+	for _, i := range next {
+		edges = append(edges, search.Edge{To: i, Cost: 1})
+	}
+	return edges
+}
+
+// explore maps the whole area with the repair droid and finds the oxygen
+// system
+func explore(r io.Reader) (*Bot, grid.Point, error) {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return nil, grid.Point{}, err
+	}
+	mem := make([]int, ramSize)
+	copy(mem, tokens)
+	m := intcode.NewMachine(mem)
+	b := NewBot(m)
+	if err := b.Explore(); err != nil {
+		return nil, grid.Point{}, err
+	}
+	goal, ok := b.Goal()
+	if !ok {
+		return nil, grid.Point{}, ErrNoOxygen
+	}
+	return b, goal, nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	b, goal, err := explore(r)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(search.BFS(b, []search.Node{grid.Point{}}, func(n search.Node) bool {
+		return n.(grid.Point) == goal
+	}).GoalDist()), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	b, goal, err := explore(r)
+	if err != nil {
+		return nil, err
+	}
+	max := 0
+	for _, d := range b.tiles.FloodFill(goal, isOpen) {
+		if d > max {
+			max = d
+		}
+	}
+	return aoc.Int(max), nil
+}
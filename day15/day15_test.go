@@ -0,0 +1,31 @@
+package day15
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func TestAnswers(t *testing.T) {
+	testutil.CheckAnswers(t, Part1, Part2)
+}
+
+func TestErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		prog     string
+		expected error
+	}{
+		{name: "halted", prog: "99", expected: ErrCrashed},
+		{name: "illegal status", prog: "3,0,104,7,99", expected: ErrStatus},
+		{name: "no oxygen", prog: "3,7,104,0,1105,1,0,0", expected: ErrNoOxygen},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Part1(strings.NewReader(tc.prog)); !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
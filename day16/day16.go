@@ -0,0 +1,61 @@
+package day16
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day16/fft"
+)
+
+const (
+	repeats = 10000
+)
+
+func SliceToNum(nums []int) int {
+	if len(nums) == 0 {
+		return -1
+	}
+	sum := 0
+	for _, i := range nums {
+		sum *= 10
+		sum += i
+	}
+	return sum
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	orignums, err := fft.ReadDigits(r)
+	if err != nil {
+		return nil, err
+	}
+	p, err := fft.New(fft.Standard, 0)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := p.Message(orignums, 100, 0, 8)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(SliceToNum(msg)), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	orignums, err := fft.ReadDigits(r)
+	if err != nil {
+		return nil, err
+	}
+	p, err := fft.New(fft.Standard, 0)
+	if err != nil {
+		return nil, err
+	}
+	sig := fft.Repeated{
+		Base:  orignums,
+		Times: repeats,
+	}
+	offset := fft.Num(sig, 0, 7)
+	msg, _, err := p.SignalMessage(sig, 100, offset, 8)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(SliceToNum(msg)), nil
+}
@@ -0,0 +1,82 @@
+package day16
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day16/fft"
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func TestPhases(t *testing.T) {
+	p, err := fft.New(fft.Standard, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signal := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	for n, expected := range [][]int{
+		{4, 8, 2, 2, 6, 1, 5, 8},
+		{3, 4, 0, 4, 0, 4, 3, 8},
+		{0, 3, 4, 1, 5, 5, 1, 8},
+		{0, 1, 0, 2, 9, 4, 9, 8},
+	} {
+		if out := p.Run(signal, n+1, 0); !reflect.DeepEqual(out, expected) {
+			t.Errorf("after %d phases: expected %v, got %v", n+1, expected, out)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	for _, tc := range []struct {
+		signal   string
+		expected int
+	}{
+		{signal: "80871224585914546619083218645595", expected: 24176176},
+		{signal: "19617804207202209144916044189917", expected: 73745418},
+		{signal: "69317163492948606335995924319873", expected: 52432133},
+	} {
+		t.Run(tc.signal, func(t *testing.T) {
+			a, err := Part1(strings.NewReader(tc.signal))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a != aoc.Int(tc.expected) {
+				t.Fatalf("expected %d, got %v", tc.expected, a)
+			}
+		})
+	}
+}
+
+func TestRealMessage(t *testing.T) {
+	for _, tc := range []struct {
+		signal   string
+		expected int
+	}{
+		{signal: "03036732577212944063491565474664", expected: 84462026},
+		{signal: "02935109699940807407585447034323", expected: 78725270},
+		{signal: "03081770884921959731165446850517", expected: 53553731},
+	} {
+		t.Run(tc.signal, func(t *testing.T) {
+			a, err := Part2(strings.NewReader(tc.signal))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a != aoc.Int(tc.expected) {
+				t.Fatalf("expected %d, got %v", tc.expected, a)
+			}
+		})
+	}
+}
+
+func TestFuzzReadDigits(t *testing.T) {
+	tokens := []string{"0", "59", "7", "a", " ", "\n", "\r\n"}
+	if err := testutil.Fuzz(tokens, func(r io.Reader) error {
+		_, err := fft.ReadDigits(r)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
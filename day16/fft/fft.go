@@ -0,0 +1,166 @@
+package fft
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+var (
+	ErrOffset  = errors.New("message does not fit in the signal")
+	ErrPattern = errors.New("invalid base pattern")
+)
+
+type (
+	// Pattern is the base pattern of a phase, where output element i repeats
+	// each value of the pattern i+1 times and skips the very first value
+	Pattern []int
+
+	Processor struct {
+		pattern Pattern
+		workers int
+	}
+)
+
+var (
+	Standard = Pattern{0, 1, 0, -1}
+)
+
+// New creates a processor that computes each phase across the given number
+// of workers, or GOMAXPROCS workers if it is not positive
+func New(pattern Pattern, workers int) (*Processor, error) {
+	if len(pattern) == 0 {
+		return nil, fmt.Errorf("%w: pattern is empty", ErrPattern)
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &Processor{
+		pattern: pattern,
+		workers: workers,
+	}, nil
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Start is the first element that every later element depends on. Element i
+// only depends on elements before it if the pattern starts with a non zero
+// value.
+func (p *Processor) Start(offset int) int {
+	if p.pattern[0] != 0 {
+		return 0
+	}
+	return offset
+}
+
+// SuffixValid reports whether every element from offset onwards is the sum
+// of all the elements after it, which is true when the pattern starts with 0
+// then 1, and the first block of ones starting at offset reaches past the
+// end of the signal
+func (p *Processor) SuffixValid(length, offset int) bool {
+	return p.suffixPattern() && 2*offset+1 >= length
+}
+
+func (p *Processor) suffixPattern() bool {
+	return p.pattern[0] == 0 && p.pattern[1%len(p.pattern)] == 1
+}
+
+// Phase computes the elements from start onwards of the next phase into dst,
+// using prefix sums so that element i sums len(src)/(i+1) blocks, for a total
+// of O(n log n)
+func (p *Processor) Phase(dst, src []int, start int) {
+	p.phase(dst[start:], src[start:], start)
+}
+
+// phase computes the next phase of the elements of a signal from start
+// onwards, where src and dst hold only those elements
+func (p *Processor) phase(dst, src []int, start int) {
+	l := start + len(src)
+	prefix := make([]int, len(src)+1)
+	for n, i := range src {
+		prefix[n+1] = prefix[n] + i
+	}
+	wg := sync.WaitGroup{}
+	for w := 0; w < p.workers; w++ {
+		wg.Add(1)
+		go func(first int) {
+			defer wg.Done()
+			for i := first; i < l; i += p.workers {
+				r := i + 1
+				sum := 0
+				for k := 0; ; k++ {
+					a := k*r - 1
+					if a >= l {
+						break
+					}
+					coef := p.pattern[k%len(p.pattern)]
+					if coef == 0 {
+						continue
+					}
+					b := a + r
+					if a < start {
+						a = start
+					}
+					if b > l {
+						b = l
+					}
+					if a < b {
+						sum += coef * (prefix[b-start] - prefix[a-start])
+					}
+				}
+				dst[i-start] = abs(sum) % 10
+			}
+		}(start + w)
+	}
+	wg.Wait()
+}
+
+// Run computes the given number of phases. Only elements from Start(offset)
+// onwards are valid in the result.
+func (p *Processor) Run(signal []int, phases int, offset int) []int {
+	start := p.Start(offset)
+	cur := make([]int, len(signal))
+	copy(cur, signal)
+	next := make([]int, len(signal))
+	for i := 0; i < phases; i++ {
+		p.Phase(next, cur, start)
+		cur, next = next, cur
+	}
+	return cur
+}
+
+// RunSuffix computes the given number of phases with the suffix sum
+// shortcut, which is only correct when SuffixValid holds
+func RunSuffix(signal []int, phases int, offset int) []int {
+	cur := make([]int, len(signal))
+	copy(cur, signal)
+	for i := 0; i < phases; i++ {
+		partial := 0
+		for n := len(cur) - 1; n >= offset; n-- {
+			partial = (partial + cur[n]) % 10
+			cur[n] = partial
+		}
+	}
+	return cur
+}
+
+// Message returns size elements at offset after the given number of phases,
+// taking the suffix sum shortcut only when it is valid
+func (p *Processor) Message(signal []int, phases int, offset, size int) ([]int, error) {
+	if offset < 0 || size < 0 || offset+size > len(signal) {
+		return nil, fmt.Errorf("%w: %d elements at %d of %d", ErrOffset, size, offset, len(signal))
+	}
+	var out []int
+	if p.SuffixValid(len(signal), offset) {
+		out = RunSuffix(signal, phases, offset)
+	} else {
+		out = p.Run(signal, phases, offset)
+	}
+	return out[offset : offset+size], nil
+}
@@ -0,0 +1,112 @@
+package fft
+
+import (
+	"errors"
+	"math/bits"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// naivePhase computes every element of the next phase directly, in O(n^2)
+func naivePhase(signal []int, pattern Pattern) []int {
+	out := make([]int, len(signal))
+	for i := range out {
+		sum := 0
+		for j, x := range signal {
+			sum += x * pattern[(j+1)/(i+1)%len(pattern)]
+		}
+		out[i] = abs(sum) % 10
+	}
+	return out
+}
+
+func randSignal(rng *rand.Rand, length int) []int {
+	signal := make([]int, length)
+	for i := range signal {
+		signal[i] = rng.Intn(10)
+	}
+	return signal
+}
+
+func TestPhaseRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, tc := range []struct {
+		name    string
+		pattern Pattern
+		workers int
+	}{
+		{name: "standard", pattern: Standard, workers: 1},
+		{name: "standard parallel", pattern: Standard, workers: 3},
+		{name: "custom", pattern: Pattern{1, 0, -2, 3, 0}, workers: 4},
+		{name: "leading zero", pattern: Pattern{0, 2, -1}, workers: 2},
+		{name: "single", pattern: Pattern{1}, workers: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := New(tc.pattern, tc.workers)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 50; i++ {
+				signal := randSignal(rng, 1+rng.Intn(120))
+				phases := 1 + rng.Intn(4)
+				offset := rng.Intn(len(signal))
+				expected := signal
+				for j := 0; j < phases; j++ {
+					expected = naivePhase(expected, tc.pattern)
+				}
+				start := p.Start(offset)
+				out := p.Run(signal, phases, offset)
+				if !reflect.DeepEqual(out[start:], expected[start:]) {
+					t.Fatalf("%v after %d phases from %d: expected %v, got %v", signal, phases, start, expected[start:], out[start:])
+				}
+			}
+		})
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	if _, err := New(Pattern{}, 1); !errors.Is(err, ErrPattern) {
+		t.Fatalf("expected %v, got %v", ErrPattern, err)
+	}
+}
+
+func TestStats(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	signal := Digits(randSignal(rng, 100))
+	custom, err := New(Pattern{1, 0, -1}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	standard, err := New(Standard, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name     string
+		p        *Processor
+		offset   int
+		shortcut bool
+		elements int
+	}{
+		{name: "second half", p: standard, offset: 60, shortcut: true, elements: 16},
+		{name: "first half", p: standard, offset: 30, shortcut: false, elements: 140},
+		{name: "custom pattern", p: custom, offset: 60, shortcut: false, elements: 200},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			msg, stats, err := tc.p.SignalMessage(signal, 3, tc.offset, 8)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := tc.p.Run(signal, 3, tc.offset)[tc.offset : tc.offset+8]; !reflect.DeepEqual(msg, expected) {
+				t.Fatalf("expected %v, got %v", expected, msg)
+			}
+			if stats.Shortcut != tc.shortcut || stats.Elements != tc.elements || stats.Bytes != tc.elements*bits.UintSize/8 {
+				t.Fatalf("expected shortcut %t with %d elements, got %+v", tc.shortcut, tc.elements, stats)
+			}
+		})
+	}
+	if _, _, err := standard.SignalMessage(signal, 1, 95, 8); !errors.Is(err, ErrOffset) {
+		t.Fatalf("expected %v, got %v", ErrOffset, err)
+	}
+}
@@ -0,0 +1,284 @@
+package fft
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+
+	"github.com/xorkevin/advent2019/parse"
+)
+
+var (
+	ErrDigit    = parse.ErrDigit
+	ErrShortcut = errors.New("suffix sum shortcut is not valid")
+)
+
+type (
+	// Signal is a sequence of digits that need not be held in memory, but
+	// may be read in any order. A signal that can only be read once, in
+	// order, is streamed with StreamMessage instead.
+	Signal interface {
+		Len() int
+		At(i int) int
+	}
+
+	// Digits is a signal held in memory
+	Digits []int
+
+	// Bytes is a signal of ASCII digits, such as the contents of an input file
+	Bytes []byte
+
+	// Repeated is a signal repeated end to end without being copied
+	Repeated struct {
+		Base  Signal
+		Times int
+	}
+
+	// Stats reports how a message was computed, and the elements of the int
+	// slices allocated while computing it
+	Stats struct {
+		Shortcut bool
+		Elements int
+		Bytes    int
+	}
+)
+
+func (s Digits) Len() int {
+	return len(s)
+}
+
+func (s Digits) At(i int) int {
+	return s[i]
+}
+
+func (s Bytes) Len() int {
+	return len(s)
+}
+
+func (s Bytes) At(i int) int {
+	return int(s[i] - '0')
+}
+
+func (s Repeated) Len() int {
+	return s.Base.Len() * s.Times
+}
+
+func (s Repeated) At(i int) int {
+	return s.Base.At(i % s.Base.Len())
+}
+
+// ReadDigits reads a signal written as a single line of digits
+func ReadDigits(r io.Reader) (Digits, error) {
+	d, err := parse.Digits(r)
+	if err != nil {
+		return nil, err
+	}
+	return Digits(d), nil
+}
+
+// Num reads size digits at offset as a decimal number
+func Num(s Signal, offset, size int) int {
+	num := 0
+	for i := offset; i < offset+size; i++ {
+		num = num*10 + s.At(i)
+	}
+	return num
+}
+
+// Materialize copies the elements of a signal from start onwards
+func Materialize(s Signal, start int) []int {
+	l := s.Len()
+	k := make([]int, 0, l-start)
+	for i := start; i < l; i++ {
+		k = append(k, s.At(i))
+	}
+	return k
+}
+
+var (
+	binom5 = [5][5]int{
+		{1, 0, 0, 0, 0},
+		{1, 1, 0, 0, 0},
+		{1, 2, 1, 0, 0},
+		{1, 3, 3, 1, 0},
+		{1, 4, 6, 4, 1},
+	}
+)
+
+// binomMod10 computes n choose k mod 10 from n choose k mod 2 and mod 5 by
+// Lucas' theorem, combined by the Chinese remainder theorem
+func binomMod10(n, k int) int {
+	m2 := 0
+	if k&^n == 0 {
+		m2 = 1
+	}
+	m5 := 1
+	for a, b := n, k; b > 0 && m5 > 0; a, b = a/5, b/5 {
+		m5 = m5 * binom5[a%5][b%5] % 5
+	}
+	return (5*m2 + 6*m5) % 10
+}
+
+// suffixSum computes the message in the suffix sum regime one element at a
+// time. After p phases, element i is the sum over d of (p-1+d choose d) times
+// element i+d, so a single pass over the suffix suffices, holding only the
+// most recent size coefficients.
+type suffixSum struct {
+	phases int
+	d      int
+	coefs  []int
+	out    []int
+}
+
+func newSuffixSum(phases, size int) *suffixSum {
+	return &suffixSum{
+		phases: phases,
+		d:      0,
+		coefs:  make([]int, size),
+		out:    make([]int, size),
+	}
+}
+
+// add adds the next element of the suffix, starting at the offset
+func (s *suffixSum) add(x int) {
+	d := s.d
+	s.d++
+	size := len(s.out)
+	if s.phases == 0 {
+		if d < size {
+			s.out[d] = x
+		}
+		return
+	}
+	s.coefs[d%size] = binomMod10(s.phases-1+d, d)
+	for k := 0; k < size && k <= d; k++ {
+		s.out[k] += s.coefs[(d-k)%size] * x
+	}
+	if d%1024 == 0 {
+		for k := range s.out {
+			s.out[k] %= 10
+		}
+	}
+}
+
+// stats reports the coefficients and output held by the suffix sum
+func (s *suffixSum) stats() Stats {
+	elements := len(s.coefs) + len(s.out)
+	return Stats{
+		Shortcut: true,
+		Elements: elements,
+		Bytes:    elements * bits.UintSize / 8,
+	}
+}
+
+func (s *suffixSum) message() []int {
+	for k := range s.out {
+		s.out[k] %= 10
+	}
+	return s.out
+}
+
+func suffixStream(s Signal, phases, offset, size int) ([]int, Stats) {
+	sum := newSuffixSum(phases, size)
+	l := s.Len()
+	for j := offset; j < l; j++ {
+		sum.add(s.At(j))
+	}
+	return sum.message(), sum.stats()
+}
+
+// SignalMessage returns size elements at offset after the given number of
+// phases without expanding the signal. When the suffix sum shortcut is valid
+// only size coefficients and size output elements are held in memory, and
+// otherwise the elements from Start(offset) onwards are, twice over.
+func (p *Processor) SignalMessage(s Signal, phases int, offset, size int) ([]int, Stats, error) {
+	l := s.Len()
+	if offset < 0 || size < 0 || offset+size > l {
+		return nil, Stats{}, fmt.Errorf("%w: %d elements at %d of %d", ErrOffset, size, offset, l)
+	}
+	if size == 0 {
+		return []int{}, Stats{}, nil
+	}
+	if p.SuffixValid(l, offset) {
+		msg, stats := suffixStream(s, phases, offset, size)
+		return msg, stats, nil
+	}
+	start := p.Start(offset)
+	cur := Materialize(s, start)
+	next := make([]int, len(cur))
+	for i := 0; i < phases; i++ {
+		p.phase(next, cur, start)
+		cur, next = next, cur
+	}
+	elements := 2 * len(cur)
+	return cur[offset-start : offset-start+size], Stats{
+		Shortcut: false,
+		Elements: elements,
+		Bytes:    elements * bits.UintSize / 8,
+	}, nil
+}
+
+// StreamMessage returns size elements at offset after the given number of
+// phases of a signal read as a single line of digits, such as an input far
+// too large to hold in memory. It reads the input once, and since it can
+// only take the suffix sum shortcut, it fails with ErrShortcut if the
+// shortcut turns out not to be valid once the length of the signal is known.
+func (p *Processor) StreamMessage(r io.Reader, phases int, offset, size int) ([]int, Stats, error) {
+	if offset < 0 || size < 0 {
+		return nil, Stats{}, fmt.Errorf("%w: %d elements at %d", ErrOffset, size, offset)
+	}
+	if !p.suffixPattern() {
+		return nil, Stats{}, fmt.Errorf("%w: pattern %v", ErrShortcut, p.pattern)
+	}
+	sum := newSuffixSum(phases, size)
+	b := bufio.NewReader(r)
+	pos := parse.Pos{
+		File: parse.NameOf(r),
+		Line: 1,
+		Col:  0,
+	}
+	l := 0
+	ended := false
+	for {
+		c, err := b.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, Stats{}, err
+		}
+		pos.Col++
+		switch {
+		case c == '\n':
+			pos.Line++
+			pos.Col = 0
+			ended = ended || l > 0
+		case c == ' ' || c == '\t' || c == '\r':
+			ended = ended || l > 0
+		case ended:
+			return nil, Stats{}, &parse.Error{
+				Pos: pos,
+				Err: fmt.Errorf("%w: expected a single line", parse.ErrExtra),
+			}
+		case c < '0' || c > '9':
+			return nil, Stats{}, &parse.Error{
+				Pos: pos,
+				Err: fmt.Errorf("%w: %q", ErrDigit, c),
+			}
+		default:
+			if l >= offset {
+				sum.add(int(c - '0'))
+			}
+			l++
+		}
+	}
+	if offset+size > l {
+		return nil, Stats{}, fmt.Errorf("%w: %d elements at %d of %d", ErrOffset, size, offset, l)
+	}
+	if !p.SuffixValid(l, offset) {
+		return nil, Stats{}, fmt.Errorf("%w: offset %d is in the first half of %d elements", ErrShortcut, offset, l)
+	}
+	return sum.message(), sum.stats(), nil
+}
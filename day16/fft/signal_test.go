@@ -0,0 +1,185 @@
+package fft
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func digitText(signal []int) string {
+	b := strings.Builder{}
+	for _, i := range signal {
+		b.WriteByte(byte('0' + i))
+	}
+	return b.String()
+}
+
+func TestSuffixValid(t *testing.T) {
+	standard, err := New(Standard, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom, err := New(Pattern{0, 2, 0, -2}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name     string
+		p        *Processor
+		length   int
+		offset   int
+		expected bool
+	}{
+		{name: "start", p: standard, length: 10, offset: 0, expected: false},
+		{name: "first half", p: standard, length: 10, offset: 4, expected: false},
+		{name: "middle", p: standard, length: 10, offset: 5, expected: true},
+		{name: "odd middle", p: standard, length: 11, offset: 5, expected: true},
+		{name: "odd first half", p: standard, length: 11, offset: 4, expected: false},
+		{name: "last", p: standard, length: 10, offset: 9, expected: true},
+		{name: "custom", p: custom, length: 10, offset: 9, expected: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if v := tc.p.SuffixValid(tc.length, tc.offset); v != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, v)
+			}
+		})
+	}
+}
+
+func TestRunSuffix(t *testing.T) {
+	p, err := New(Standard, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		signal := randSignal(rng, 1+rng.Intn(200))
+		l := len(signal)
+		offset := l/2 + rng.Intn(l-l/2)
+		if !p.SuffixValid(l, offset) {
+			t.Fatalf("offset %d of %d is in the second half", offset, l)
+		}
+		phases := rng.Intn(5)
+		expected := p.Run(signal, phases, offset)[offset:]
+		if out := RunSuffix(signal, phases, offset)[offset:]; !reflect.DeepEqual(out, expected) {
+			t.Fatalf("%v after %d phases from %d: expected %v, got %v", signal, phases, offset, expected, out)
+		}
+		size := l - offset
+		if size > 8 {
+			size = 8
+		}
+		if out, _ := suffixStream(Digits(signal), phases, offset, size); !reflect.DeepEqual(out, expected[:size]) {
+			t.Fatalf("%v streamed after %d phases from %d: expected %v, got %v", signal, phases, offset, expected[:size], out)
+		}
+	}
+}
+
+func TestBinomMod10(t *testing.T) {
+	row := []int{1}
+	for n := 0; n < 200; n++ {
+		for k, expected := range row {
+			if c := binomMod10(n, k); c != expected {
+				t.Fatalf("%d choose %d: expected %d, got %d", n, k, expected, c)
+			}
+		}
+		next := make([]int, len(row)+1)
+		next[0] = 1
+		for k := 1; k < len(row); k++ {
+			next[k] = (row[k-1] + row[k]) % 10
+		}
+		next[len(row)] = 1
+		row = next
+	}
+}
+
+func TestRepeated(t *testing.T) {
+	p, err := New(Standard, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a signal 100 times longer than the puzzle's, which is never expanded
+	sig := Repeated{
+		Base:  Bytes("59719811742386712072322509550573967421647565332667367184388997335292349852954113343804787102604664096288440135472284308373326245877593956199225516071210882728614292871131765110416999817460140955856338830118060988497097324334962543389288979535054141495171461720836525090700092901849537843081841755954360811618153200442803197286399570023355821961989595705705045742262477597293974158696594795118783767300148414702347570064139665680516053143032825288231685962359393267461932384683218413483205671636464298057303588424278653449749781937014234119757220011471950196190313903906218080178644004164122665292870495547666700781057929319060171363468213087408071790"),
+		Times: 1000000,
+	}
+	l := sig.Len()
+	offset := l - 1000
+	msg, stats, err := p.SignalMessage(sig, 100, offset, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stats.Shortcut || stats.Elements != 16 {
+		t.Fatalf("expected the shortcut holding 16 elements, got %+v", stats)
+	}
+	if expected := RunSuffix(Materialize(sig, offset), 100, 0)[:8]; !reflect.DeepEqual(msg, expected) {
+		t.Fatalf("expected %v, got %v", expected, msg)
+	}
+	if n := Num(sig, l-3, 3); n != 790 {
+		t.Fatalf("expected 790, got %d", n)
+	}
+}
+
+func TestStreamMessage(t *testing.T) {
+	p, err := New(Standard, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		signal := randSignal(rng, 20+rng.Intn(200))
+		l := len(signal)
+		offset := l/2 + rng.Intn(l-l/2-7)
+		phases := rng.Intn(5)
+		expected, _, err := p.SignalMessage(Digits(signal), phases, offset, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg, stats, err := p.StreamMessage(strings.NewReader(" "+digitText(signal)+"\r\n"), phases, offset, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(msg, expected) {
+			t.Fatalf("%v after %d phases from %d: expected %v, got %v", signal, phases, offset, expected, msg)
+		}
+		if !stats.Shortcut || stats.Elements != 16 {
+			t.Fatalf("expected the shortcut holding 16 elements, got %+v", stats)
+		}
+	}
+
+	custom, err := New(Pattern{1, 0, -1}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name   string
+		p      *Processor
+		input  string
+		offset int
+		err    error
+		pos    string
+	}{
+		{name: "first half", p: p, input: "0123456789\n", offset: 4, err: ErrShortcut},
+		{name: "pattern", p: custom, input: "0123456789\n", offset: 8, err: ErrShortcut},
+		{name: "offset", p: p, input: "0123456789\n", offset: 9, err: ErrOffset},
+		{name: "digit", p: p, input: "01234x6789\n", offset: 2, err: ErrDigit, pos: "stream.txt:1:6"},
+		{name: "extra", p: p, input: "0123456789\n\n 12\n", offset: 2, err: parse.ErrExtra, pos: "stream.txt:3:2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := tc.p.StreamMessage(parse.Named("stream.txt", strings.NewReader(tc.input)), 1, tc.offset, 2)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+			if tc.pos == "" {
+				return
+			}
+			var perr *parse.Error
+			if !errors.As(err, &perr) || perr.Pos.String() != tc.pos {
+				t.Fatalf("expected error at %s, got %v", tc.pos, err)
+			}
+		})
+	}
+}
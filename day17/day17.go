@@ -0,0 +1,280 @@
+package day17
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/grid"
+	"github.com/xorkevin/advent2019/intcode"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+const (
+	ramSize = 8192
+	// maxRoutine is the most characters the robot accepts for a routine,
+	// not counting the newline
+	maxRoutine = 20
+	maxFuncs   = 3
+)
+
+var (
+	ErrNoDust     = errors.New("robot did not report collected dust")
+	ErrNoRoutines = errors.New("path does not split into movement routines")
+)
+
+type (
+	Bot struct {
+		grid *grid.Dense
+		pos  grid.Point
+		dir  grid.Dir
+	}
+)
+
+func isBot(c byte) bool {
+	return c == '^'
+}
+
+func isPath(c byte) bool {
+	return c == '#'
+}
+
+func NewBot(g *grid.Dense) *Bot {
+	pos := grid.Point{X: -1, Y: -1}
+	if bots := g.FindFunc(isBot); len(bots) > 0 {
+		pos = bots[0]
+	}
+	return &Bot{
+		grid: g,
+		pos:  pos,
+		dir:  grid.Up,
+	}
+}
+
+func (b *Bot) isPath(p grid.Point) bool {
+	return b.grid.InBounds(p) && isPath(b.grid.At(p))
+}
+
+func (b *Bot) isIntersection(p grid.Point) bool {
+	return b.isPath(p) && len(b.grid.Neighbors4(p, isPath)) == 4
+}
+
+func (b *Bot) Sum() int {
+	sum := 0
+	for _, i := range b.grid.FindFunc(isPath) {
+		if b.isIntersection(i) {
+			sum += i.X * i.Y
+		}
+	}
+	return sum
+}
+
+func (b *Bot) getFLR() (bool, bool, bool) {
+	return b.isPath(b.pos.Step(b.dir, 1)), b.isPath(b.pos.Step(b.dir.Left(), 1)), b.isPath(b.pos.Step(b.dir.Right(), 1))
+}
+
+func (b *Bot) FindDirections() string {
+	instrs := bytes.Buffer{}
+	for {
+		f, l, r := b.getFLR()
+		if !f && !l && !r {
+			break
+		}
+
+		if f {
+			b.pos = b.pos.Step(b.dir, 1)
+			instrs.WriteByte('F')
+		} else if l {
+			b.dir = b.dir.Left()
+			instrs.WriteByte('L')
+		} else {
+			b.dir = b.dir.Right()
+			instrs.WriteByte('R')
+		}
+	}
+
+	run := 0
+	s := strings.Builder{}
+	for _, i := range instrs.Bytes() {
+		if i == 'F' {
+			run++
+			continue
+		}
+		if run > 0 {
+			s.WriteString(strconv.Itoa(run))
+			s.WriteByte(',')
+			run = 0
+		}
+		s.WriteByte(i)
+		s.WriteByte(',')
+	}
+	if run > 0 {
+		s.WriteString(strconv.Itoa(run))
+		s.WriteByte(',')
+		run = 0
+	}
+	return s.String()
+}
+
+// Routines splits a path from FindDirections into a main routine calling
+// movement functions A, B and C, each short enough for the robot's memory
+func Routines(path string) (string, []string, error) {
+	moves := strings.FieldsFunc(path, func(r rune) bool {
+		return r == ','
+	})
+	calls, funcs, ok := compress(moves, nil, nil)
+	if !ok || len(moves) == 0 {
+		return "", nil, fmt.Errorf("%w: %s", ErrNoRoutines, path)
+	}
+	names := make([]string, 0, len(calls))
+	for _, i := range calls {
+		names = append(names, string(rune('A'+i)))
+	}
+	routines := make([]string, 0, len(funcs))
+	for _, i := range funcs {
+		routines = append(routines, strings.Join(i, ","))
+	}
+	return strings.Join(names, ","), routines, nil
+}
+
+// compress covers the remaining moves with calls to the functions found so
+// far, trying each function that matches next before defining a new one from
+// the next moves, longest first
+func compress(moves []string, calls []int, funcs [][]string) ([]int, [][]string, bool) {
+	if len(moves) == 0 {
+		return calls, funcs, true
+	}
+	if 2*len(calls)+1 > maxRoutine {
+		return nil, nil, false
+	}
+	for n, i := range funcs {
+		if !hasPrefix(moves, i) {
+			continue
+		}
+		if c, f, ok := compress(moves[len(i):], append(calls, n), funcs); ok {
+			return c, f, true
+		}
+	}
+	if len(funcs) == maxFuncs {
+		return nil, nil, false
+	}
+	l := 0
+	for l < len(moves) && len(strings.Join(moves[:l+1], ",")) <= maxRoutine {
+		l++
+	}
+	for ; l > 0; l-- {
+		next := append(funcs[:len(funcs):len(funcs)], moves[:l])
+		if c, f, ok := compress(moves[l:], append(calls, len(funcs)), next); ok {
+			return c, f, true
+		}
+	}
+	return nil, nil, false
+}
+
+func hasPrefix(moves, prefix []string) bool {
+	if len(prefix) > len(moves) {
+		return false
+	}
+	for n, i := range prefix {
+		if moves[n] != i {
+			return false
+		}
+	}
+	return true
+}
+
+// scaffold reads the camera view of the scaffolding
+func scaffold(tokens []int) (*grid.Dense, error) {
+	mem := make([]int, ramSize)
+	copy(mem, tokens)
+	m := intcode.NewMachine(mem)
+	lines := [][]byte{}
+	line := []byte{}
+	for {
+		out, ok := m.Read()
+		if !ok {
+			break
+		}
+		switch byte(out) {
+		case '\n':
+			if len(line) > 0 {
+				lines = append(lines, line)
+				line = []byte{}
+			}
+		default:
+			line = append(line, byte(out))
+		}
+	}
+	if err := m.Err(); err != nil {
+		return nil, err
+	}
+	return grid.FromLines(lines, '.'), nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return nil, err
+	}
+	g, err := scaffold(tokens)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(NewBot(g).Sum()), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return nil, err
+	}
+	g, err := scaffold(tokens)
+	if err != nil {
+		return nil, err
+	}
+	routine, funcs, err := Routines(NewBot(g).FindDirections())
+	if err != nil {
+		return nil, err
+	}
+	// the robot expects all three functions even if the path needs fewer
+	for len(funcs) < maxFuncs {
+		funcs = append(funcs, funcs[0])
+	}
+	mem := make([]int, ramSize)
+	copy(mem, tokens)
+	m := intcode.NewMachine(mem)
+	mem[0] = 2
+	for _, i := range append([]string{routine}, funcs...) {
+		for _, c := range []byte(i) {
+			m.Write(int(c))
+		}
+		m.Write('\n')
+	}
+	for _, c := range []byte("n\n") {
+		m.Write(int(c))
+	}
+	text := strings.Builder{}
+	dust := -1
+	for {
+		out, ok := m.Read()
+		if !ok {
+			break
+		}
+		if out > 255 {
+			dust = out
+		} else {
+			text.WriteRune(rune(out))
+		}
+	}
+	if err := m.Err(); err != nil {
+		return nil, err
+	}
+	if dust < 0 {
+		return nil, fmt.Errorf("%w:\n%s", ErrNoDust, text.String())
+	}
+	return aoc.Int(dust), nil
+}
@@ -0,0 +1,58 @@
+package day17
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/grid"
+	"github.com/xorkevin/advent2019/internal/testutil"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func parseFile(t *testing.T, name string) *grid.Dense {
+	t.Helper()
+	g, err := parse.Grid(testutil.ReadFile(t, name), '.', nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestSum(t *testing.T) {
+	if sum := NewBot(parseFile(t, "intersections.txt")).Sum(); sum != 76 {
+		t.Fatalf("expected 76, got %d", sum)
+	}
+}
+
+func TestFindDirections(t *testing.T) {
+	expected := "R,8,R,8,R,4,R,4,R,8,L,6,L,2,R,4,R,4,R,8,R,8,R,8,L,6,L,2,"
+	if path := NewBot(parseFile(t, "path.txt")).FindDirections(); path != expected {
+		t.Fatalf("expected %s, got %s", expected, path)
+	}
+}
+
+func TestRoutines(t *testing.T) {
+	path := NewBot(parseFile(t, "path.txt")).FindDirections()
+	routine, funcs, err := Routines(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routine) > maxRoutine || len(funcs) > maxFuncs {
+		t.Fatalf("main routine %q with %d functions does not fit", routine, len(funcs))
+	}
+	moves := []string{}
+	for _, i := range strings.Split(routine, ",") {
+		f := funcs[i[0]-'A']
+		if len(f) > maxRoutine {
+			t.Fatalf("function %s %q is too long", i, f)
+		}
+		moves = append(moves, f)
+	}
+	if expanded := strings.Join(moves, ",") + ","; expanded != path {
+		t.Fatalf("expected %s, got %s", path, expanded)
+	}
+	if _, _, err := Routines("L,1,R,2,L,3,R,4,L,5,R,6,L,7,R,8,L,9,R,10,L,11,R,12,L,13,R,14,L,15,R,16,"); !errors.Is(err, ErrNoRoutines) {
+		t.Fatalf("expected %v, got %v", ErrNoRoutines, err)
+	}
+}
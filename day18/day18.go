@@ -0,0 +1,442 @@
+package day18
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/grid"
+	"github.com/xorkevin/advent2019/parse"
+	"github.com/xorkevin/advent2019/search"
+)
+
+var (
+	ErrUnreachable  = errors.New("not every key can be collected")
+	ErrDuplicateKey = errors.New("duplicate key")
+)
+
+type (
+	Maze struct {
+		grid    *grid.Dense
+		enter   []grid.Point
+		keys    []byte
+		keyPos  map[byte]grid.Point
+		doorPos map[byte]grid.Point
+	}
+)
+
+func isEntrance(c byte) bool {
+	return c == '@'
+}
+
+func isKey(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+func isDoor(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+func isWall(c byte) bool {
+	return c == '#'
+}
+
+func isOpen(c byte) bool {
+	return !isWall(c)
+}
+
+func isTile(c byte) bool {
+	return c == '.' || isWall(c) || isEntrance(c) || isKey(c) || isDoor(c)
+}
+
+// NewMaze finds the entrances, keys and doors of a vault map read from the
+// named input
+func NewMaze(g *grid.Dense, name string) (*Maze, error) {
+	keys := []byte{}
+	keyPos := map[byte]grid.Point{}
+	doorPos := map[byte]grid.Point{}
+
+	for _, i := range g.FindFunc(func(c byte) bool {
+		return isKey(c) || isDoor(c)
+	}) {
+		c := g.At(i)
+		if isKey(c) {
+			if k, ok := keyPos[c]; ok {
+				return nil, &parse.Error{
+					Pos: parse.Pos{
+						File: name,
+						Line: i.Y + 1,
+						Col:  i.X + 1,
+					},
+					Err: fmt.Errorf("%w: %c, also at %d:%d", ErrDuplicateKey, c, k.Y+1, k.X+1),
+				}
+			}
+			keys = append(keys, c)
+			keyPos[c] = i
+		} else {
+			doorPos[c] = i
+		}
+	}
+
+	return &Maze{
+		grid:    g,
+		enter:   g.FindFunc(isEntrance),
+		keys:    keys,
+		keyPos:  keyPos,
+		doorPos: doorPos,
+	}, nil
+}
+
+func (m *Maze) at(pos grid.Point) byte {
+	return m.grid.At(pos)
+}
+
+// Neighbors returns the open tiles next to a tile, ignoring doors
+func (m *Maze) Neighbors(n search.Node) []search.Edge {
+	next := m.grid.Neighbors4(n.(grid.Point), isOpen)
+	edges := make([]search.Edge, 0, len(next))
+	for _, k := range next {
+		edges = append(edges, search.Edge{To: k, Cost: 1})
+	}
+	return edges
+}
+
+// SplitEntrance replaces a lone entrance and its neighbors with four
+// entrances in the corners, walled off from each other. A maze that already
+// has more than one entrance is left as is, with a robot at each.
+func (m *Maze) SplitEntrance() error {
+	if len(m.enter) == 0 {
+		return fmt.Errorf("maze has no entrance")
+	}
+	if len(m.enter) > 1 {
+		return nil
+	}
+	p := m.enter[0]
+	for _, i := range p.Neighbors8() {
+		if k := m.at(i); k != '.' && !isEntrance(k) {
+			return fmt.Errorf("entrance at %d,%d is not surrounded by open space", p.X, p.Y)
+		}
+	}
+	m.grid.Set(p, '#')
+	for _, i := range p.Neighbors4() {
+		m.grid.Set(i, '#')
+	}
+	m.enter = []grid.Point{
+		{X: p.X - 1, Y: p.Y - 1},
+		{X: p.X + 1, Y: p.Y - 1},
+		{X: p.X - 1, Y: p.Y + 1},
+		{X: p.X + 1, Y: p.Y + 1},
+	}
+	for _, i := range m.enter {
+		m.grid.Set(i, '@')
+	}
+	return nil
+}
+
+func keyBit(c byte) uint32 {
+	return 1 << uint(c-'a')
+}
+
+func doorBit(c byte) uint32 {
+	return 1 << uint(c-'A')
+}
+
+type (
+	// KeyEdge is the shortest walk from a node to a key, with the doors it
+	// passes through and the other keys it passes over
+	KeyEdge struct {
+		to    int
+		key   byte
+		dist  int
+		doors uint32
+		keys  uint32
+	}
+
+	// KeyGraph has a node for each entrance followed by a node for each key,
+	// with edges from every node to every key reachable from it
+	KeyGraph struct {
+		maze    *Maze
+		nodes   []grid.Point
+		robots  int
+		edges   [][]KeyEdge
+		allKeys uint32
+	}
+)
+
+// walk finds the shortest walk from start to every key, ignoring doors but
+// recording them
+func (m *Maze) walk(start grid.Point, nodeOf map[byte]int) []KeyEdge {
+	r := search.BFS(m, []search.Node{start}, nil)
+	edges := []KeyEdge{}
+	for _, i := range m.keys {
+		goal := m.keyPos[i]
+		if goal == start {
+			continue
+		}
+		d, ok := r.Dist(goal)
+		if !ok {
+			continue
+		}
+		e := KeyEdge{
+			to:   nodeOf[i],
+			key:  i,
+			dist: d,
+		}
+		path := r.Path(goal)
+		for _, k := range path[1 : len(path)-1] {
+			c := m.at(k.(grid.Point))
+			if isDoor(c) {
+				e.doors |= doorBit(c)
+			} else if isKey(c) {
+				e.keys |= keyBit(c)
+			}
+		}
+		edges = append(edges, e)
+	}
+	return edges
+}
+
+func (m *Maze) KeyGraph() (*KeyGraph, error) {
+	if len(m.keys) > 26 {
+		return nil, fmt.Errorf("too many keys: %d", len(m.keys))
+	}
+	if len(m.enter) == 0 {
+		return nil, fmt.Errorf("maze has no entrance")
+	}
+	g := &KeyGraph{
+		maze:   m,
+		nodes:  make([]grid.Point, 0, len(m.enter)+len(m.keys)),
+		robots: len(m.enter),
+	}
+	nodeOf := map[byte]int{}
+	g.nodes = append(g.nodes, m.enter...)
+	for _, i := range m.keys {
+		nodeOf[i] = len(g.nodes)
+		g.nodes = append(g.nodes, m.keyPos[i])
+		g.allKeys |= keyBit(i)
+	}
+	g.edges = make([][]KeyEdge, 0, len(g.nodes))
+	for _, i := range g.nodes {
+		g.edges = append(g.edges, m.walk(i, nodeOf))
+	}
+	return g, nil
+}
+
+type (
+	// VaultState is the node each robot stands on and the keys collected so
+	// far
+	VaultState struct {
+		pos  string
+		keys uint32
+	}
+
+	// stateMove is the robot and edge that lead from one state to the next
+	stateMove struct {
+		robot int
+		edge  KeyEdge
+	}
+
+	// Leg is a single robot walking to collect a key
+	Leg struct {
+		Robot int
+		Key   byte
+		Dist  int
+		Total int
+		Path  []grid.Point
+	}
+
+	// Route is the order in which the robots collect every key
+	Route struct {
+		Steps int
+		Legs  []Leg
+	}
+)
+
+// Neighbors returns the states reached by any one robot collecting a key it
+// can reach, with every door on the way unlocked. Keys passed over on the
+// way must already be collected, since a route collecting them first is
+// never longer.
+func (g *KeyGraph) Neighbors(n search.Node) []search.Edge {
+	cur := n.(VaultState)
+	edges := []search.Edge{}
+	for r := 0; r < g.robots; r++ {
+		node := int(cur.pos[r])
+		for _, e := range g.edges[node] {
+			bit := keyBit(e.key)
+			if cur.keys&bit != 0 {
+				continue
+			}
+			if e.doors&^cur.keys != 0 || e.keys&^cur.keys != 0 {
+				continue
+			}
+			nextPos := []byte(cur.pos)
+			nextPos[r] = byte(e.to)
+			edges = append(edges, search.Edge{
+				To: VaultState{
+					pos:  string(nextPos),
+					keys: cur.keys | bit,
+				},
+				Cost: e.dist,
+				Data: stateMove{
+					robot: r,
+					edge:  e,
+				},
+			})
+		}
+	}
+	return edges
+}
+
+// Solve finds the route with the fewest steps for the robots to collect
+// every key, by Dijkstra over the robot positions and the set of keys
+// collected
+func (g *KeyGraph) Solve() (*Route, bool) {
+	startPos := make([]byte, g.robots)
+	for i := range startPos {
+		startPos[i] = byte(i)
+	}
+	start := VaultState{
+		pos:  string(startPos),
+		keys: 0,
+	}
+	r := search.Dijkstra(g, []search.Node{start}, func(n search.Node) bool {
+		return n.(VaultState).keys == g.allKeys
+	})
+	if !r.Found {
+		return nil, false
+	}
+	return g.route(r), true
+}
+
+func (g *KeyGraph) route(r *search.Result) *Route {
+	steps := r.Steps(r.Goal)
+	legs := make([]Leg, 0, len(steps))
+	total := 0
+	for _, i := range steps {
+		move := i.Data.(stateMove)
+		from := g.nodes[int(i.From.(VaultState).pos[move.robot])]
+		total += move.edge.dist
+		legs = append(legs, Leg{
+			Robot: move.robot,
+			Key:   move.edge.key,
+			Dist:  move.edge.dist,
+			Total: total,
+			Path:  g.maze.path(from, g.nodes[move.edge.to]),
+		})
+	}
+	return &Route{
+		Steps: r.GoalDist(),
+		Legs:  legs,
+	}
+}
+
+// path finds the tiles of a shortest walk from start to goal, ignoring
+// doors, including both ends
+func (m *Maze) path(start, goal grid.Point) []grid.Point {
+	r := search.BFS(m, []search.Node{start}, func(n search.Node) bool {
+		return n.(grid.Point) == goal
+	})
+	nodes := r.Path(goal)
+	path := make([]grid.Point, 0, len(nodes))
+	for _, i := range nodes {
+		path = append(path, i.(grid.Point))
+	}
+	return path
+}
+
+// Frames renders the maze before the route and after each leg, with the
+// robots as @, the tiles of the last leg as +, and collected keys and their
+// doors cleared
+func (m *Maze) Frames(route *Route) []string {
+	tiles := m.grid.Clone()
+	robots := make([]grid.Point, len(m.enter))
+	copy(robots, m.enter)
+	for _, i := range robots {
+		tiles.Set(i, '.')
+	}
+	render := func(header string, trail []grid.Point) string {
+		frame := tiles.Clone()
+		for _, i := range trail {
+			frame.Set(i, '+')
+		}
+		for _, i := range robots {
+			frame.Set(i, '@')
+		}
+		return header + "\n" + frame.String()
+	}
+	frames := make([]string, 0, len(route.Legs)+1)
+	frames = append(frames, render("step 0", nil))
+	for _, leg := range route.Legs {
+		k := m.keyPos[leg.Key]
+		tiles.Set(k, '.')
+		header := fmt.Sprintf("step %d: robot %d collects %c", leg.Total, leg.Robot, leg.Key)
+		door := leg.Key - 'a' + 'A'
+		if d, ok := m.doorPos[door]; ok {
+			tiles.Set(d, '.')
+			header += fmt.Sprintf(", opening %c", door)
+		}
+		robots[leg.Robot] = k
+		frames = append(frames, render(header, leg.Path))
+	}
+	return frames
+}
+
+// Replay writes every frame of the route, clearing the terminal and pausing
+// between frames when delay is positive
+func (m *Maze) Replay(w io.Writer, route *Route, delay time.Duration) error {
+	for n, i := range m.Frames(route) {
+		if delay > 0 {
+			if n > 0 {
+				time.Sleep(delay)
+			}
+			if _, err := io.WriteString(w, "\x1b[H\x1b[2J"); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parse reads a vault map, where # is a wall, . is open, @ is an entrance, a
+// lowercase letter is a key and an uppercase letter is its door
+func Parse(r io.Reader) (*Maze, error) {
+	tiles, err := parse.Grid(r, '#', isTile)
+	if err != nil {
+		return nil, err
+	}
+	return NewMaze(tiles, parse.NameOf(r))
+}
+
+func collect(r io.Reader, split bool) (aoc.Answer, error) {
+	maze, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	if split {
+		if err := maze.SplitEntrance(); err != nil {
+			return nil, err
+		}
+	}
+	g, err := maze.KeyGraph()
+	if err != nil {
+		return nil, err
+	}
+	route, ok := g.Solve()
+	if !ok {
+		return nil, ErrUnreachable
+	}
+	return aoc.Int(route.Steps), nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	return collect(r, false)
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	return collect(r, true)
+}
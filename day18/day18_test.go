@@ -0,0 +1,135 @@
+package day18
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/internal/testutil"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func TestCollect(t *testing.T) {
+	for _, tc := range []struct {
+		file     string
+		split    bool
+		expected int
+	}{
+		{file: "one1.txt", expected: 8},
+		{file: "one2.txt", expected: 86},
+		{file: "one3.txt", expected: 132},
+		{file: "one4.txt", expected: 136},
+		{file: "one5.txt", expected: 81},
+		{file: "split1.txt", split: true, expected: 8},
+		{file: "four2.txt", expected: 24},
+		{file: "four2.txt", split: true, expected: 24},
+		{file: "four3.txt", expected: 32},
+		{file: "four4.txt", expected: 72},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			a, err := collect(testutil.ReadFile(t, tc.file), tc.split)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a != aoc.Int(tc.expected) {
+				t.Fatalf("expected %d, got %v", tc.expected, a)
+			}
+		})
+	}
+}
+
+func TestRoute(t *testing.T) {
+	m, err := Parse(testutil.ReadFile(t, "one2.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := m.KeyGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	route, ok := g.Solve()
+	if !ok {
+		t.Fatal("no route")
+	}
+	keys := []byte{}
+	for _, i := range route.Legs {
+		keys = append(keys, i.Key)
+	}
+	if string(keys) != "abcdef" {
+		t.Fatalf("expected keys in order abcdef, got %s", keys)
+	}
+	frames := m.Frames(route)
+	if len(frames) != len(route.Legs)+1 {
+		t.Fatalf("expected %d frames, got %d", len(route.Legs)+1, len(frames))
+	}
+	last := frames[len(frames)-1]
+	if tiles := last[strings.IndexByte(last, '\n'):]; strings.ContainsAny(tiles, "abcdefABCDEF") {
+		t.Fatalf("keys or doors remain after the route:\n%s", last)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	m, err := Parse(testutil.ReadFile(t, "one1.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := m.KeyGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	route, ok := g.Solve()
+	if !ok {
+		t.Fatal("no route")
+	}
+	b := bytes.Buffer{}
+	if err := m.Replay(&b, route, 0); err != nil {
+		t.Fatal(err)
+	}
+	expected := strings.Join([]string{
+		"step 0",
+		"#########",
+		"#b.A.@.a#",
+		"#########",
+		"step 2: robot 0 collects a, opening A",
+		"#########",
+		"#b...++@#",
+		"#########",
+		"step 8: robot 0 collects b",
+		"#########",
+		"#@++++++#",
+		"#########",
+		"",
+	}, "\n")
+	if b.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, b.String())
+	}
+}
+
+func TestSplitEntranceInvalid(t *testing.T) {
+	if _, err := collect(strings.NewReader("#####\n#a.b#\n#####\n"), true); err == nil {
+		t.Fatal("split of a maze without an entrance accepted")
+	}
+}
+
+func TestDuplicateKey(t *testing.T) {
+	_, err := Parse(parse.Named("maze.txt", strings.NewReader("#######\n#a.@.a#\n#######\n")))
+	if !errors.Is(err, ErrDuplicateKey) {
+		t.Fatalf("expected %v, got %v", ErrDuplicateKey, err)
+	}
+	if pos := "maze.txt:2:6"; !strings.HasPrefix(err.Error(), pos) {
+		t.Fatalf("expected error at %s, got %v", pos, err)
+	}
+}
+
+func TestFuzzParse(t *testing.T) {
+	tokens := []string{"#", ".", "@", "a", "B", "#########", "?", "\n"}
+	if err := testutil.Fuzz(tokens, func(r io.Reader) error {
+		_, err := Parse(r)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
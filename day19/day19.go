@@ -0,0 +1,137 @@
+package day19
+
+import (
+	"errors"
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day19/probe"
+	"github.com/xorkevin/advent2019/grid"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+const (
+	ramSize    = 8192
+	squareSize = 100
+)
+
+var (
+	// scanArea is the area scanned in part 1
+	scanArea = grid.Rect{
+		Min: grid.Point{X: 0, Y: 0},
+		Max: grid.Point{X: 49, Y: 49},
+	}
+	// searchArea bounds the search for the square in part 2
+	searchArea = grid.Rect{
+		Min: grid.Point{X: 0, Y: 0},
+		Max: grid.Point{X: 9999, Y: 9999},
+	}
+)
+
+var (
+	ErrNoOutput = errors.New("drone did not report")
+	ErrNoSquare = errors.New("no square fits in the beam within the search area")
+)
+
+// FitSquare finds the top left corner of the square of the given size
+// nearest the emitter that fits in the beam. It follows the left edge of the
+// beam down from the first row the square can reach, and stops at the first
+// row where the top right corner of the square standing on the edge is also
+// in the beam. The edge never moves left, so each row is scanned from the
+// edge of the last, and rows near the emitter without any beam within the
+// bounds are skipped.
+func FitSquare(size int, bounds grid.Rect, inBeam func(p grid.Point) (bool, error)) (grid.Point, error) {
+	x := bounds.Min.X
+	for y := bounds.Min.Y + size - 1; y <= bounds.Max.Y; y++ {
+		edge := -1
+		for k := x; k <= bounds.Max.X; k++ {
+			ok, err := inBeam(grid.Point{X: k, Y: y})
+			if err != nil {
+				return grid.Point{}, err
+			}
+			if ok {
+				edge = k
+				break
+			}
+		}
+		if edge < 0 {
+			continue
+		}
+		x = edge
+		corner := grid.Point{X: x + size - 1, Y: y - size + 1}
+		if !bounds.Contains(corner) {
+			break
+		}
+		ok, err := inBeam(corner)
+		if err != nil {
+			return grid.Point{}, err
+		}
+		if ok {
+			return grid.Point{X: x, Y: corner.Y}, nil
+		}
+	}
+	return grid.Point{}, ErrNoSquare
+}
+
+// probeBeam probes a single point
+func probeBeam(pool *probe.Pool) func(p grid.Point) (bool, error) {
+	return func(p grid.Point) (bool, error) {
+		out, err := pool.Probe(p.X, p.Y)
+		if err != nil {
+			return false, err
+		}
+		if len(out) == 0 {
+			return false, ErrNoOutput
+		}
+		return out[0] == 1, nil
+	}
+}
+
+// scan probes every point in a rectangle and returns the points the beam
+// reaches
+func scan(pool *probe.Pool, r grid.Rect) (*grid.Sparse, error) {
+	inputs := make([][]int, 0, r.Width()*r.Height())
+	for y := r.Min.Y; y <= r.Max.Y; y++ {
+		for x := r.Min.X; x <= r.Max.X; x++ {
+			inputs = append(inputs, []int{x, y})
+		}
+	}
+	outputs, err := pool.ProbeAll(inputs)
+	if err != nil {
+		return nil, err
+	}
+	beam := grid.NewSparse()
+	for n, out := range outputs {
+		if len(out) == 0 {
+			return nil, ErrNoOutput
+		}
+		if out[0] == 1 {
+			beam.Set(grid.Point{X: inputs[n][0], Y: inputs[n][1]}, 1)
+		}
+	}
+	return beam, nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return nil, err
+	}
+	beam, err := scan(probe.NewPool(tokens, ramSize, 0), scanArea)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(beam.Len()), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return nil, err
+	}
+	pos, err := FitSquare(squareSize, searchArea, probeBeam(probe.NewPool(tokens, ramSize, 1)))
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(pos.X*10000 + pos.Y), nil
+}
@@ -0,0 +1,116 @@
+package day19
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/xorkevin/advent2019/day19/probe"
+	"github.com/xorkevin/advent2019/grid"
+	"github.com/xorkevin/advent2019/internal/testutil"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func readProgram(b *testing.B) []int {
+	b.Helper()
+	tokens, err := parse.CSVInts(testutil.ReadInput(b))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return tokens
+}
+
+func TestAnswers(t *testing.T) {
+	testutil.CheckAnswers(t, Part1, Part2)
+}
+
+// cone is a beam between two lines through the emitter, with slopes given
+// as x per 10 rows
+func cone(left, right int) func(p grid.Point) (bool, error) {
+	return func(p grid.Point) (bool, error) {
+		return p.X*10 >= p.Y*left && p.X*10 <= p.Y*right, nil
+	}
+}
+
+func TestFitSquare(t *testing.T) {
+	bounds := grid.Rect{
+		Min: grid.Point{X: 0, Y: 0},
+		Max: grid.Point{X: 199, Y: 199},
+	}
+	for _, tc := range []struct {
+		name  string
+		left  int
+		right int
+		size  int
+		err   error
+	}{
+		{name: "narrow", left: 7, right: 9, size: 5},
+		{name: "wide", left: 2, right: 25, size: 10},
+		{name: "steep", left: 30, right: 40, size: 4},
+		{name: "too thin", left: 10, right: 10, size: 2, err: ErrNoSquare},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			inBeam := cone(tc.left, tc.right)
+			// the nearest square by brute force, found row by row
+			var expected *grid.Point
+			for y := bounds.Min.Y; y <= bounds.Max.Y && expected == nil; y++ {
+				for x := bounds.Min.X; x <= bounds.Max.X; x++ {
+					a, _ := inBeam(grid.Point{X: x, Y: y + tc.size - 1})
+					b, _ := inBeam(grid.Point{X: x + tc.size - 1, Y: y})
+					if a && b {
+						expected = &grid.Point{X: x, Y: y}
+						break
+					}
+				}
+			}
+			pos, err := FitSquare(tc.size, bounds, inBeam)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected == nil || pos != *expected {
+				t.Fatalf("expected %v, got %v", expected, pos)
+			}
+		})
+	}
+}
+
+// BenchmarkScan compares the 50x50 scan of part 1 across a pool of machines
+// against probing every point in turn with a single machine
+func BenchmarkScan(b *testing.B) {
+	tokens := readProgram(b)
+	b.Run("pool", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := scan(probe.NewPool(tokens, ramSize, 0), scanArea); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("single", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m := probe.NewMachine(tokens, ramSize)
+			beam := grid.NewSparse()
+			for y := scanArea.Min.Y; y <= scanArea.Max.Y; y++ {
+				for x := scanArea.Min.X; x <= scanArea.Max.X; x++ {
+					m.Reset()
+					out, err := m.Run([]int{x, y})
+					if err != nil {
+						b.Fatal(err)
+					}
+					if len(out) == 0 {
+						b.Fatal(ErrNoOutput)
+					}
+					if out[0] == 1 {
+						beam.Set(grid.Point{X: x, Y: y}, 1)
+					}
+				}
+			}
+		}
+	})
+}
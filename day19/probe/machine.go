@@ -0,0 +1,45 @@
+package probe
+
+import (
+	"github.com/xorkevin/advent2019/intcode"
+)
+
+type (
+	// Machine is an intcode machine that can be reset to its pristine memory
+	// image and run again without reallocating.
+	Machine struct {
+		m        *intcode.Machine
+		pristine []int
+	}
+)
+
+func NewMachine(prog []int, ramSize int) *Machine {
+	if ramSize < len(prog) {
+		ramSize = len(prog)
+	}
+	pristine := make([]int, ramSize)
+	copy(pristine, prog)
+	return newMachineFromImage(pristine)
+}
+
+func newMachineFromImage(pristine []int) *Machine {
+	mem := make([]int, len(pristine))
+	copy(mem, pristine)
+	return &Machine{
+		m:        intcode.NewMachine(mem),
+		pristine: pristine,
+	}
+}
+
+func (m *Machine) Reset() {
+	m.m.Reset(m.pristine)
+}
+
+// Run executes the machine until it halts and returns a fresh copy of its
+// outputs
+func (m *Machine) Run(inputs []int) ([]int, error) {
+	for _, i := range inputs {
+		m.m.Write(i)
+	}
+	return m.m.Outputs()
+}
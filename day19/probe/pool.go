@@ -0,0 +1,88 @@
+package probe
+
+import (
+	"runtime"
+	"sync"
+)
+
+type (
+	// Pool holds a fixed set of machines loaded with the same program, each of
+	// which is reset from a shared pristine memory image before every probe
+	Pool struct {
+		machines chan *Machine
+		workers  int
+	}
+)
+
+// NewPool creates a pool of size machines, or GOMAXPROCS machines if size is
+// not positive
+func NewPool(prog []int, ramSize int, size int) *Pool {
+	if size <= 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+	if ramSize < len(prog) {
+		ramSize = len(prog)
+	}
+	pristine := make([]int, ramSize)
+	copy(pristine, prog)
+	machines := make(chan *Machine, size)
+	for i := 0; i < size; i++ {
+		machines <- newMachineFromImage(pristine)
+	}
+	return &Pool{
+		machines: machines,
+		workers:  size,
+	}
+}
+
+func (p *Pool) Workers() int {
+	return p.workers
+}
+
+// Probe runs the program once to completion with the given inputs, blocking
+// until a machine is available
+func (p *Pool) Probe(inputs ...int) ([]int, error) {
+	m := <-p.machines
+	defer func() {
+		p.machines <- m
+	}()
+	m.Reset()
+	return m.Run(inputs)
+}
+
+// ProbeAll runs every input set across the pool's workers and returns the
+// outputs in the same order as the inputs
+func (p *Pool) ProbeAll(inputs [][]int) ([][]int, error) {
+	outputs := make([][]int, len(inputs))
+	errs := make([]error, p.workers)
+	jobs := make(chan int, p.workers)
+	wg := sync.WaitGroup{}
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := range jobs {
+				if errs[worker] != nil {
+					continue
+				}
+				out, err := p.Probe(inputs[j]...)
+				if err != nil {
+					errs[worker] = err
+					continue
+				}
+				outputs[j] = out
+			}
+		}(i)
+	}
+	for i := range inputs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return outputs, nil
+}
@@ -0,0 +1,91 @@
+package probe
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/xorkevin/advent2019/intcode"
+)
+
+var (
+	// sumProg reads two inputs and outputs their sum
+	sumProg = []int{3, 20, 3, 21, 1, 20, 21, 22, 4, 22, 99}
+	// countProg increments a counter in its own memory and outputs it
+	countProg = []int{1001, 20, 1, 20, 4, 20, 99}
+)
+
+func TestMachineRun(t *testing.T) {
+	m := NewMachine(sumProg, 32)
+	out, err := m.Run([]int{3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []int{7}; !reflect.DeepEqual(out, expected) {
+		t.Fatalf("expected %v, got %v", expected, out)
+	}
+}
+
+func TestMachineReset(t *testing.T) {
+	m := NewMachine(countProg, 32)
+	for i := 0; i < 3; i++ {
+		m.Reset()
+		out, err := m.Run(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := []int{1}; !reflect.DeepEqual(out, expected) {
+			t.Fatalf("expected %v, got %v", expected, out)
+		}
+		// the outputs returned must not share memory with the machine
+		out[0] = 42
+	}
+}
+
+func TestMachineErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		prog   []int
+		inputs []int
+		err    error
+	}{
+		{name: "input exhausted", prog: sumProg, inputs: []int{3}, err: intcode.ErrNoInput},
+		{name: "illegal op", prog: []int{98}, err: intcode.ErrIllegalOp},
+		{name: "illegal mode", prog: []int{11101, 1, 1, 0, 99}, err: intcode.ErrIllegalMode},
+		{name: "mem bounds", prog: []int{4, 1000, 99}, err: intcode.ErrMemBounds},
+		{name: "pc bounds", prog: []int{1105, 1, 1000}, err: intcode.ErrMemBounds},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMachine(tc.prog, 32)
+			if _, err := m.Run(tc.inputs); !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestProbeAll(t *testing.T) {
+	p := NewPool(sumProg, 32, 4)
+	inputs := make([][]int, 0, 500)
+	for i := 0; i < cap(inputs); i++ {
+		inputs = append(inputs, []int{i, 2 * i})
+	}
+	outputs, err := p.ProbeAll(inputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n, i := range outputs {
+		if expected := []int{3 * n}; !reflect.DeepEqual(i, expected) {
+			t.Fatalf("input %d: expected %v, got %v", n, expected, i)
+		}
+	}
+
+	inputs[123] = []int{1}
+	if _, err := p.ProbeAll(inputs); !errors.Is(err, intcode.ErrNoInput) {
+		t.Fatalf("expected %v, got %v", intcode.ErrNoInput, err)
+	}
+	// every machine is returned to the pool after a failed probe
+	if out, err := p.Probe(1, 2); err != nil || !reflect.DeepEqual(out, []int{3}) {
+		t.Fatalf("expected [3], got %v, %v", out, err)
+	}
+}
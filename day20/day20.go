@@ -0,0 +1,30 @@
+package day20
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day20/portal"
+)
+
+func solve(r io.Reader, opts portal.Options) (aoc.Answer, error) {
+	m, err := portal.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	route, err := m.Graph().Solve(opts)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(route.Steps), nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	return solve(r, portal.Options{})
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	return solve(r, portal.Options{
+		Recursive: true,
+	})
+}
@@ -0,0 +1,100 @@
+package day20
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day20/portal"
+	"github.com/xorkevin/advent2019/internal/testutil"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func TestSolve(t *testing.T) {
+	for _, tc := range []struct {
+		file     string
+		part     int
+		expected int
+	}{
+		{file: "small.txt", part: 1, expected: 23},
+		{file: "small.txt", part: 2, expected: 26},
+		{file: "recursive.txt", part: 2, expected: 396},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			solve := Part1
+			if tc.part == 2 {
+				solve = Part2
+			}
+			a, err := solve(testutil.ReadFile(t, tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a != aoc.Int(tc.expected) {
+				t.Fatalf("part %d: expected %d, got %v", tc.part, tc.expected, a)
+			}
+		})
+	}
+}
+
+func TestLabels(t *testing.T) {
+	m, err := portal.Parse(testutil.ReadFile(t, "small.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if labels := m.Labels(); !reflect.DeepEqual(labels, []string{"AA", "BC", "DE", "FG", "ZZ"}) {
+		t.Fatalf("unexpected labels %v", labels)
+	}
+	outer := map[string]int{}
+	for _, i := range m.Endpoints() {
+		if i.Outer {
+			outer[i.Label]++
+		}
+		if (i.Label == "AA" || i.Label == "ZZ") && !i.Outer {
+			t.Errorf("%s at %v is not on the outer edge", i.Label, i.Pos)
+		}
+	}
+	for _, i := range []string{"BC", "DE", "FG"} {
+		if outer[i] != 1 {
+			t.Errorf("%s has %d outer endpoints, expected 1", i, outer[i])
+		}
+	}
+}
+
+func TestFuzzParse(t *testing.T) {
+	tokens := []string{"#", ".", " ", "A", "AA", "ZZ", "BC.", "?", "\n"}
+	if err := testutil.Fuzz(tokens, func(r io.Reader) error {
+		_, err := portal.Parse(r)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLabelErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		maze string
+		pos  string
+	}{
+		{name: "single letter", maze: "  A\n  .\n  #\n", pos: "maze.txt:1:3"},
+		{name: "two labels", maze: " AB.CD\n", pos: "maze.txt:1:4"},
+		{name: "three tiles", maze: " A A A\n B B B\n . . .\n", pos: "maze.txt:2:6"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := portal.Parse(parse.Named("maze.txt", strings.NewReader(tc.maze)))
+			if !errors.Is(err, portal.ErrLabel) {
+				t.Fatalf("expected %v, got %v", portal.ErrLabel, err)
+			}
+			var perr *parse.Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("error without a position: %v", err)
+			}
+			if perr.Pos.String() != tc.pos {
+				t.Fatalf("expected %s, got %s", tc.pos, perr.Pos)
+			}
+		})
+	}
+}
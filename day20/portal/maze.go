@@ -0,0 +1,173 @@
+package portal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/xorkevin/advent2019/grid"
+	"github.com/xorkevin/advent2019/parse"
+	"github.com/xorkevin/advent2019/search"
+)
+
+var (
+	ErrLabel   = errors.New("invalid portal label")
+	ErrNoLabel = errors.New("no portal with label")
+	ErrNoRoute = errors.New("no route")
+	ErrDepth   = errors.New("invalid max depth")
+)
+
+type (
+	// Endpoint is one side of a labelled portal, at the open tile next to
+	// the label
+	Endpoint struct {
+		Label string
+		Pos   grid.Point
+		Outer bool
+	}
+
+	Maze struct {
+		grid      *grid.Dense
+		endpoints []Endpoint
+		byLabel   map[string][]int
+		byPos     map[grid.Point]int
+		bounds    grid.Rect
+	}
+)
+
+func isPath(c byte) bool {
+	return c == '.'
+}
+
+func isWall(c byte) bool {
+	return c == '#'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
+
+func isTile(c byte) bool {
+	return isPath(c) || isWall(c)
+}
+
+func isValid(c byte) bool {
+	return c == ' ' || isTile(c) || isLetter(c)
+}
+
+// Parse reads a donut maze. A label is two letters in a line next to an open
+// tile, read left to right or top to bottom, on any side of the tile.
+func Parse(r io.Reader) (*Maze, error) {
+	name := parse.NameOf(r)
+	g, err := parse.Grid(r, ' ', isValid)
+	if err != nil {
+		return nil, err
+	}
+	return NewMaze(g, name)
+}
+
+// labelError locates an invalid label at a point of the grid of an input
+func labelError(name string, p grid.Point, format string, args ...interface{}) error {
+	return &parse.Error{
+		Pos: parse.Pos{
+			File: name,
+			Line: p.Y + 1,
+			Col:  p.X + 1,
+		},
+		Err: fmt.Errorf("%w: "+format, append([]interface{}{ErrLabel}, args...)...),
+	}
+}
+
+// NewMaze finds the portals of a maze read from the input with the given
+// name, which locates any invalid label
+func NewMaze(g *grid.Dense, name string) (*Maze, error) {
+	m := &Maze{
+		grid:    g,
+		byLabel: map[string][]int{},
+		byPos:   map[grid.Point]int{},
+	}
+	m.bounds, _ = grid.Bounds(g.FindFunc(isTile))
+	for _, p := range g.FindFunc(isPath) {
+		for _, d := range grid.Dirs {
+			a := g.At(p.Step(d, 1))
+			b := g.At(p.Step(d, 2))
+			if !isLetter(a) {
+				continue
+			}
+			if !isLetter(b) {
+				return nil, labelError(name, p.Step(d, 1), "single letter %c", a)
+			}
+			label := string([]byte{a, b})
+			if d == grid.Up || d == grid.Left {
+				label = string([]byte{b, a})
+			}
+			if _, ok := m.byPos[p]; ok {
+				return nil, labelError(name, p, "tile has more than one label")
+			}
+			if l := m.byLabel[label]; len(l) == 2 {
+				return nil, labelError(name, p.Step(d, 1), "%s labels more than 2 tiles", label)
+			}
+			m.byPos[p] = len(m.endpoints)
+			m.byLabel[label] = append(m.byLabel[label], len(m.endpoints))
+			m.endpoints = append(m.endpoints, Endpoint{
+				Label: label,
+				Pos:   p,
+				Outer: m.bounds.OnEdge(p),
+			})
+		}
+	}
+	return m, nil
+}
+
+func (m *Maze) Endpoints() []Endpoint {
+	return m.endpoints
+}
+
+// Labels returns every label in the maze in sorted order
+func (m *Maze) Labels() []string {
+	labels := make([]string, 0, len(m.byLabel))
+	for k := range m.byLabel {
+		labels = append(labels, k)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// Partner returns the other endpoint of a portal
+func (m *Maze) Partner(n int) (int, bool) {
+	l := m.byLabel[m.endpoints[n].Label]
+	if len(l) != 2 {
+		return 0, false
+	}
+	if l[0] == n {
+		return l[1], true
+	}
+	return l[0], true
+}
+
+// Neighbors returns the open tiles next to a tile
+func (m *Maze) Neighbors(n search.Node) []search.Edge {
+	next := m.grid.Neighbors4(n.(grid.Point), isPath)
+	edges := make([]search.Edge, 0, len(next))
+	for _, k := range next {
+		edges = append(edges, search.Edge{To: k, Cost: 1})
+	}
+	return edges
+}
+
+// walk finds the walking distance from an endpoint to every other endpoint
+// reachable from it without passing through a portal
+func (m *Maze) walk(n int) map[int]int {
+	r := search.BFS(m, []search.Node{m.endpoints[n].Pos}, nil)
+	found := map[int]int{}
+	for k, v := range m.endpoints {
+		if k == n {
+			continue
+		}
+		if d, ok := r.Dist(v.Pos); ok {
+			found[k] = d
+		}
+	}
+	return found
+}
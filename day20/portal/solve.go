@@ -0,0 +1,213 @@
+package portal
+
+import (
+	"fmt"
+
+	"github.com/xorkevin/advent2019/search"
+)
+
+const (
+	HopWalk = iota
+	HopWarp
+)
+
+type (
+	// Options configure a solve. Start and End default to AA and ZZ. When
+	// Recursive is set, inner portals lead one level down and outer portals
+	// one level up, outer portals are walls on the outermost level, and the
+	// search never goes deeper than MaxDepth. A MaxDepth of 0 defaults to
+	// the square of the number of portals, which no shortest route exceeds.
+	Options struct {
+		Start     string
+		End       string
+		Recursive bool
+		MaxDepth  int
+	}
+
+	// Hop is a walk between two endpoints on one level, or a warp through a
+	// portal between levels
+	Hop struct {
+		Kind      int
+		From, To  Endpoint
+		FromLevel int
+		ToLevel   int
+		Dist      int
+		Total     int
+	}
+
+	Route struct {
+		Steps int
+		Hops  []Hop
+	}
+
+	edge struct {
+		to   int
+		dist int
+	}
+
+	// Graph is the maze compressed to its endpoints, with the walking
+	// distances between them precomputed
+	Graph struct {
+		maze  *Maze
+		edges [][]edge
+	}
+
+	state struct {
+		node  int
+		level int
+	}
+)
+
+func (m *Maze) Graph() *Graph {
+	g := &Graph{
+		maze:  m,
+		edges: make([][]edge, 0, len(m.endpoints)),
+	}
+	for n := range m.endpoints {
+		found := m.walk(n)
+		edges := make([]edge, 0, len(found))
+		for k, v := range found {
+			edges = append(edges, edge{
+				to:   k,
+				dist: v,
+			})
+		}
+		g.edges = append(g.edges, edges)
+	}
+	return g
+}
+
+// defaultDepth bounds the levels a shortest route can reach. Every level
+// the route descends to on its way to its deepest level is entered through
+// one portal and later left through another. If two levels were entered and
+// left through the same pair of portals, the walk between them on the
+// shallower level could be replaced by the shorter walk nested within it on
+// the deeper level, so on a shortest route every level has its own pair.
+func (m *Maze) defaultDepth() int {
+	portals := 0
+	for _, v := range m.byLabel {
+		if len(v) == 2 {
+			portals++
+		}
+	}
+	return portals * portals
+}
+
+// Solve finds the shortest route from the start label to the end label on
+// the outermost level, by Dijkstra over endpoints and levels
+func (g *Graph) Solve(opts Options) (*Route, error) {
+	m := g.maze
+	if opts.Start == "" {
+		opts.Start = "AA"
+	}
+	if opts.End == "" {
+		opts.End = "ZZ"
+	}
+	if opts.MaxDepth < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrDepth, opts.MaxDepth)
+	}
+	if opts.MaxDepth == 0 {
+		opts.MaxDepth = m.defaultDepth()
+	}
+	starts, ok := m.byLabel[opts.Start]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrNoLabel, opts.Start)
+	}
+	ends, ok := m.byLabel[opts.End]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrNoLabel, opts.End)
+	}
+	isEnd := map[int]struct{}{}
+	for _, i := range ends {
+		isEnd[i] = struct{}{}
+	}
+
+	startNodes := make([]search.Node, 0, len(starts))
+	for _, i := range starts {
+		startNodes = append(startNodes, state{node: i, level: 0})
+	}
+	expand := search.GraphFunc(func(n search.Node) []search.Edge {
+		return g.neighbors(n.(state), opts)
+	})
+	r := search.Dijkstra(expand, startNodes, func(n search.Node) bool {
+		s := n.(state)
+		_, ok := isEnd[s.node]
+		return ok && s.level == 0
+	})
+	if !r.Found {
+		return nil, fmt.Errorf("%w from %s to %s", ErrNoRoute, opts.Start, opts.End)
+	}
+	steps := r.Steps(r.Goal)
+	hops := make([]Hop, 0, len(steps))
+	total := 0
+	for _, i := range steps {
+		hop := i.Data.(Hop)
+		total += hop.Dist
+		hop.Total = total
+		hops = append(hops, hop)
+	}
+	return &Route{
+		Steps: r.GoalDist(),
+		Hops:  hops,
+	}, nil
+}
+
+// neighbors returns the walks to other endpoints on the same level, and the
+// warp through the portal if it is open on this level
+func (g *Graph) neighbors(cur state, opts Options) []search.Edge {
+	m := g.maze
+	from := m.endpoints[cur.node]
+	edges := make([]search.Edge, 0, len(g.edges[cur.node])+1)
+	for _, e := range g.edges[cur.node] {
+		edges = append(edges, search.Edge{
+			To:   state{node: e.to, level: cur.level},
+			Cost: e.dist,
+			Data: Hop{
+				Kind:      HopWalk,
+				From:      from,
+				To:        m.endpoints[e.to],
+				FromLevel: cur.level,
+				ToLevel:   cur.level,
+				Dist:      e.dist,
+			},
+		})
+	}
+	partner, ok := m.Partner(cur.node)
+	if !ok {
+		return edges
+	}
+	level := cur.level
+	if opts.Recursive {
+		if from.Outer {
+			level--
+		} else {
+			level++
+		}
+		if level < 0 || level > opts.MaxDepth {
+			return edges
+		}
+	}
+	return append(edges, search.Edge{
+		To:   state{node: partner, level: level},
+		Cost: 1,
+		Data: Hop{
+			Kind:      HopWarp,
+			From:      from,
+			To:        m.endpoints[partner],
+			FromLevel: cur.level,
+			ToLevel:   level,
+			Dist:      1,
+		},
+	})
+}
+
+// MaxLevel returns the deepest level the route reaches
+func (r *Route) MaxLevel() int {
+	max := 0
+	for _, i := range r.Hops {
+		if i.ToLevel > max {
+			max = i.ToLevel
+		}
+	}
+	return max
+}
@@ -0,0 +1,118 @@
+package portal
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func parseFile(t *testing.T, name string) *Maze {
+	t.Helper()
+	// the samples are shared with the day20 package
+	m, err := Parse(testutil.ReadFile(t, filepath.Join("..", "..", "testdata", name)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestSolve(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		file     string
+		opts     Options
+		expected int
+		err      error
+	}{
+		{name: "flat", file: "small.txt", opts: Options{}, expected: 23},
+		{name: "recursive", file: "small.txt", opts: Options{Recursive: true}, expected: 26},
+		{name: "reversed", file: "small.txt", opts: Options{Start: "ZZ", End: "AA"}, expected: 23},
+		{name: "portal", file: "small.txt", opts: Options{End: "FG"}, expected: 16},
+		{name: "missing start", file: "small.txt", opts: Options{Start: "XY"}, err: ErrNoLabel},
+		{name: "missing end", file: "small.txt", opts: Options{End: "XY"}, err: ErrNoLabel},
+		{name: "negative depth", file: "small.txt", opts: Options{Recursive: true, MaxDepth: -1}, err: ErrDepth},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			route, err := parseFile(t, tc.file).Graph().Solve(tc.opts)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if route.Steps != tc.expected {
+				t.Fatalf("expected %d, got %d", tc.expected, route.Steps)
+			}
+			total := 0
+			for _, i := range route.Hops {
+				total += i.Dist
+				if i.Total != total {
+					t.Fatalf("expected a running total of %d, got %d", total, i.Total)
+				}
+			}
+			if total != route.Steps {
+				t.Fatalf("expected hops totalling %d, got %d", route.Steps, total)
+			}
+		})
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	for _, tc := range []struct {
+		file     string
+		depth    int
+		expected int
+		level    int
+		err      error
+	}{
+		{file: "small.txt", depth: 0, expected: 26, level: 0},
+		{file: "recursive.txt", depth: 0, expected: 396, level: 10},
+		{file: "recursive.txt", depth: 10, expected: 396, level: 10},
+		{file: "recursive.txt", depth: 9, err: ErrNoRoute},
+		{file: "recursive.txt", depth: 1, err: ErrNoRoute},
+	} {
+		t.Run(fmt.Sprintf("%s/%d", tc.file, tc.depth), func(t *testing.T) {
+			route, err := parseFile(t, tc.file).Graph().Solve(Options{
+				Recursive: true,
+				MaxDepth:  tc.depth,
+			})
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if route.Steps != tc.expected || route.MaxLevel() != tc.level {
+				t.Fatalf("expected %d steps reaching level %d, got %d reaching %d", tc.expected, tc.level, route.Steps, route.MaxLevel())
+			}
+		})
+	}
+}
+
+func TestPartner(t *testing.T) {
+	m := parseFile(t, "small.txt")
+	for n, i := range m.Endpoints() {
+		p, ok := m.Partner(n)
+		if i.Label == "AA" || i.Label == "ZZ" {
+			if ok {
+				t.Fatalf("%s has a partner", i.Label)
+			}
+			continue
+		}
+		if !ok {
+			t.Fatalf("%s at %v has no partner", i.Label, i.Pos)
+		}
+		if o := m.Endpoints()[p]; o.Label != i.Label || o.Outer == i.Outer {
+			t.Fatalf("expected the other side of %s, got %+v", i.Label, o)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package day21
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/intcode"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+const (
+	ramSize = 8192
+)
+
+const (
+	walkScript = `NOT C J
+NOT B T
+OR J T
+NOT A J
+OR J T
+AND D T
+NOT T T
+NOT T J
+WALK
+`
+	runScript = `NOT C J
+NOT B T
+OR J T
+NOT A J
+OR J T
+AND D T
+NOT E J
+NOT J J
+OR H J
+AND T J
+RUN
+`
+)
+
+var (
+	ErrFell = errors.New("springdroid fell into space")
+)
+
+// survey runs a springscript program and returns the hull damage the droid
+// reports, or the droid's last moments as an error if it falls into space
+func survey(r io.Reader, script string) (aoc.Answer, error) {
+	tokens, err := parse.CSVInts(r)
+	if err != nil {
+		return nil, err
+	}
+	mem := make([]int, ramSize)
+	copy(mem, tokens)
+	m := intcode.NewMachine(mem)
+	for _, i := range []byte(script) {
+		m.Write(int(i))
+	}
+	text := strings.Builder{}
+	damage := -1
+	for {
+		out, ok := m.Read()
+		if !ok {
+			break
+		}
+		if out < 256 {
+			text.WriteRune(rune(out))
+		} else {
+			damage = out
+		}
+	}
+	if err := m.Err(); err != nil {
+		return nil, err
+	}
+	if damage < 0 {
+		return nil, fmt.Errorf("%w:\n%s", ErrFell, text.String())
+	}
+	return aoc.Int(damage), nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	return survey(r, walkScript)
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	return survey(r, runScript)
+}
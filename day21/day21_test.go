@@ -0,0 +1,19 @@
+package day21
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/internal/testutil"
+)
+
+func TestAnswers(t *testing.T) {
+	testutil.CheckAnswers(t, Part1, Part2)
+}
+
+func TestFell(t *testing.T) {
+	if _, err := Part1(strings.NewReader("104,68,99")); !errors.Is(err, ErrFell) {
+		t.Fatalf("expected %v, got %v", ErrFell, err)
+	}
+}
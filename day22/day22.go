@@ -0,0 +1,153 @@
+package day22
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+
+	"github.com/xorkevin/advent2019/aoc"
+)
+
+const (
+	deckSize1 = 10007
+	deckSize2 = 119315717514047
+	shuffles2 = 101741582076661
+)
+
+var (
+	ErrNotInvertible = errors.New("shuffle is not invertible")
+)
+
+type (
+	// Card is the affine map y = a x + b (mod size) from the position of a
+	// card before a shuffle to its position after
+	Card struct {
+		a, b int64
+		size int64
+	}
+)
+
+func NewCard(size int64) *Card {
+	return &Card{
+		a:    1,
+		b:    0,
+		size: size,
+	}
+}
+
+func mod(a, m int64) int64 {
+	a %= m
+	if a < 0 {
+		a += m
+	}
+	return a
+}
+
+// mulmod computes a * b mod m for a, b in [0, m) without overflow
+func mulmod(a, b, m int64) int64 {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	_, rem := bits.Div64(hi, lo, uint64(m))
+	return int64(rem)
+}
+
+func (c *Card) Reverse() {
+	c.a = mod(-c.a, c.size)
+	c.b = mod(-c.b-1, c.size)
+}
+
+func (c *Card) Cut(n int64) {
+	c.b = mod(c.b-mod(n, c.size), c.size)
+}
+
+func (c *Card) DealIncr(n int64) {
+	n = mod(n, c.size)
+	c.a = mulmod(c.a, n, c.size)
+	c.b = mulmod(c.b, n, c.size)
+}
+
+// Compose returns the shuffle that applies c and then o
+func (c *Card) Compose(o *Card) *Card {
+	return &Card{
+		a:    mulmod(o.a, c.a, c.size),
+		b:    mod(mulmod(o.a, c.b, c.size)+o.b, c.size),
+		size: c.size,
+	}
+}
+
+// Pow returns the shuffle c applied k times, by repeated squaring
+func (c *Card) Pow(k int64) *Card {
+	result := NewCard(c.size)
+	base := &Card{
+		a:    c.a,
+		b:    c.b,
+		size: c.size,
+	}
+	for k > 0 {
+		if k&1 == 1 {
+			result = result.Compose(base)
+		}
+		base = base.Compose(base)
+		k >>= 1
+	}
+	return result
+}
+
+// FindCard returns the position of card n after the shuffle
+func (c *Card) FindCard(n int64) int64 {
+	return mod(mulmod(mod(n, c.size), c.a, c.size)+c.b, c.size)
+}
+
+// CardAt returns the card at position n after the shuffle
+func (c *Card) CardAt(n int64) (int64, error) {
+	inv, err := c.Inverse()
+	if err != nil {
+		return 0, err
+	}
+	return inv.FindCard(n), nil
+}
+
+// y = a x + b
+// x = y*a' - b*a'
+func (c *Card) Inverse() (*Card, error) {
+	k := new(big.Int).ModInverse(big.NewInt(c.a), big.NewInt(c.size))
+	if k == nil {
+		return nil, fmt.Errorf("%w: %d has no inverse mod %d", ErrNotInvertible, c.a, c.size)
+	}
+	a := k.Int64()
+	b := mod(-mulmod(c.b, a, c.size), c.size)
+	return &Card{
+		a:    a,
+		b:    b,
+		size: c.size,
+	}, nil
+}
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	techs, err := ParseTechniques(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateTechniques(techs, deckSize1); err != nil {
+		return nil, err
+	}
+	deck := NewCardFrom(techs, deckSize1)
+	return aoc.Int(deck.FindCard(2019)), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	techs, err := ParseTechniques(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateTechniques(techs, deckSize2); err != nil {
+		return nil, err
+	}
+	deck := NewCardFrom(techs, deckSize2).Pow(shuffles2)
+	card, err := deck.CardAt(2020)
+	if err != nil {
+		return nil, err
+	}
+	return aoc.Int(card), nil
+}
@@ -0,0 +1,190 @@
+package day22
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2019/internal/testutil"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func randTechniques(r *rand.Rand, size int64) []Technique {
+	techs := []Technique{}
+	for i := r.Intn(12) + 1; i > 0; i-- {
+		switch r.Intn(3) {
+		case 0:
+			techs = append(techs, Technique{Kind: techNewStack})
+		case 1:
+			techs = append(techs, Technique{Kind: techCut, N: r.Int63n(2*size-1) - size + 1})
+		case 2:
+			n := r.Int63n(size-1) + 1
+			for gcd(n, size) != 1 {
+				n = r.Int63n(size-1) + 1
+			}
+			techs = append(techs, Technique{Kind: techIncr, N: n})
+		}
+	}
+	return techs
+}
+
+func TestCardDifferential(t *testing.T) {
+	r := rand.New(rand.NewSource(2019))
+	for trial := 0; trial < 500; trial++ {
+		size := r.Int63n(200) + 2
+		techs := randTechniques(r, size)
+		if err := ValidateTechniques(techs, size); err != nil {
+			t.Fatal(err)
+		}
+		repeats := r.Intn(5) + 1
+		deck := NewDeck(int(size))
+		for i := 0; i < repeats; i++ {
+			deck = deck.Shuffle(techs)
+		}
+		card := NewCardFrom(techs, size).Pow(int64(repeats))
+		for pos, c := range deck {
+			if p := card.FindCard(int64(c)); p != int64(pos) {
+				t.Fatalf("size %d %v x%d: expected card %d at %d, got %d", size, techs, repeats, c, pos, p)
+			}
+			at, err := card.CardAt(int64(pos))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if at != int64(c) {
+				t.Fatalf("size %d %v x%d: expected position %d to hold %d, got %d", size, techs, repeats, pos, c, at)
+			}
+		}
+	}
+}
+
+func TestCardInverse(t *testing.T) {
+	r := rand.New(rand.NewSource(22))
+	size := int64(10007)
+	for trial := 0; trial < 50; trial++ {
+		card := NewCardFrom(randTechniques(r, size), size)
+		inv, err := card.Inverse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := card.Compose(inv)
+		if id.a != 1 || id.b != 0 {
+			t.Fatalf("expected identity, got %d x + %d", id.a, id.b)
+		}
+	}
+}
+
+func TestCardLarge(t *testing.T) {
+	card := NewCard(deckSize2)
+	card.DealIncr(deckSize2 - 1)
+	card.Cut(-deckSize2 + 3)
+	card.DealIncr(deckSize2 - 2)
+	k := card.Pow(shuffles2)
+	inv, err := k.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []int64{0, 2020, deckSize2 - 1} {
+		if p := inv.FindCard(k.FindCard(c)); p != c {
+			t.Fatalf("expected card %d to round trip, got %d", c, p)
+		}
+	}
+}
+
+func TestCardExamples(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected Deck
+	}{
+		{
+			input:    "deal with increment 7\ndeal into new stack\ndeal into new stack\n",
+			expected: Deck{0, 3, 6, 9, 2, 5, 8, 1, 4, 7},
+		},
+		{
+			input:    "cut 6\ndeal with increment 7\ndeal into new stack\n",
+			expected: Deck{3, 0, 7, 4, 1, 8, 5, 2, 9, 6},
+		},
+		{
+			input:    "deal with increment 7\ndeal with increment 9\ncut -2\n",
+			expected: Deck{6, 3, 0, 7, 4, 1, 8, 5, 2, 9},
+		},
+		{
+			input:    "deal into new stack\ncut -2\ndeal with increment 7\ncut 8\ncut -4\ndeal with increment 7\ncut 3\ndeal with increment 9\ndeal with increment 3\ncut -1\n",
+			expected: Deck{9, 2, 5, 8, 1, 4, 7, 0, 3, 6},
+		},
+	} {
+		techs, err := ParseTechniques(strings.NewReader(tc.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		deck := NewDeck(10).Shuffle(techs)
+		card := NewCardFrom(techs, 10)
+		for pos, c := range tc.expected {
+			if deck[pos] != c {
+				t.Errorf("%v: expected reference card %d at %d, got %d", techs, c, pos, deck[pos])
+			}
+			if p := card.FindCard(int64(c)); p != int64(pos) {
+				t.Errorf("%v: expected card %d at %d, got %d", techs, c, pos, p)
+			}
+		}
+	}
+}
+
+func TestParseTechniques(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		line  int
+		col   int
+	}{
+		{input: "cut 3\ndeal into new stack\n", line: 0},
+		{input: "cut 3\nshuffle 4\n", line: 2, col: 1},
+		{input: "cut 3\n  deal with incremnt 4\n", line: 2, col: 13},
+		{input: "cut x\n", line: 1, col: 5},
+		{input: "deal into new stack now\n", line: 1, col: 21},
+		{input: "\ndeal with increment\n", line: 2, col: 20},
+	} {
+		_, err := ParseTechniques(strings.NewReader(tc.input))
+		if tc.line == 0 {
+			if err != nil {
+				t.Errorf("%q: unexpected error %v", tc.input, err)
+			}
+			continue
+		}
+		var perr *parse.Error
+		if !errors.As(err, &perr) {
+			t.Errorf("%q: expected parse error, got %v", tc.input, err)
+			continue
+		}
+		if perr.Line != tc.line || perr.Col != tc.col {
+			t.Errorf("%q: expected error at %d:%d, got %v", tc.input, tc.line, tc.col, perr)
+		}
+	}
+}
+
+func TestValidateTechniques(t *testing.T) {
+	techs, err := ParseTechniques(strings.NewReader("cut 3\ndeal with increment 4\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateTechniques(techs, 7); err != nil {
+		t.Fatal(err)
+	}
+	var perr *parse.Error
+	if err := ValidateTechniques(techs, 10); !errors.As(err, &perr) || perr.Line != 2 {
+		t.Fatalf("expected error on line 2, got %v", err)
+	}
+}
+
+func TestFuzzParseTechniques(t *testing.T) {
+	tokens := []string{"deal", "into", "new", "stack", "with", "increment", "cut", "-", "7", "10007", " ", "\n"}
+	if err := testutil.Fuzz(tokens, func(r io.Reader) error {
+		techs, err := ParseTechniques(r)
+		if err != nil {
+			return err
+		}
+		return ValidateTechniques(techs, 10007)
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,71 @@
+package day22
+
+type (
+	// Deck is the reference shuffle, which moves every card of an actual
+	// deck
+	Deck []int
+)
+
+func NewDeck(size int) Deck {
+	d := make(Deck, size)
+	for i := range d {
+		d[i] = i
+	}
+	return d
+}
+
+func (d Deck) Reverse() Deck {
+	next := make(Deck, len(d))
+	for n, i := range d {
+		next[len(d)-1-n] = i
+	}
+	return next
+}
+
+func (d Deck) Cut(n int64) Deck {
+	k := int(mod(n, int64(len(d))))
+	next := make(Deck, 0, len(d))
+	next = append(next, d[k:]...)
+	return append(next, d[:k]...)
+}
+
+func (d Deck) DealIncr(n int64) Deck {
+	next := make(Deck, len(d))
+	k := int(mod(n, int64(len(d))))
+	pos := 0
+	for _, i := range d {
+		next[pos] = i
+		pos = (pos + k) % len(d)
+	}
+	return next
+}
+
+func (d Deck) Apply(t Technique) Deck {
+	switch t.Kind {
+	case techNewStack:
+		return d.Reverse()
+	case techCut:
+		return d.Cut(t.N)
+	case techIncr:
+		return d.DealIncr(t.N)
+	default:
+		return d
+	}
+}
+
+func (d Deck) Shuffle(techs []Technique) Deck {
+	for _, t := range techs {
+		d = d.Apply(t)
+	}
+	return d
+}
+
+// Find returns the position of a card, or -1 if it is not in the deck
+func (d Deck) Find(card int) int {
+	for n, i := range d {
+		if i == card {
+			return n
+		}
+	}
+	return -1
+}
@@ -0,0 +1,171 @@
+package day22
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/xorkevin/advent2019/parse"
+)
+
+var (
+	ErrTechnique = errors.New("invalid shuffle technique")
+)
+
+const (
+	techNewStack = iota
+	techCut
+	techIncr
+)
+
+type (
+	// Technique is a single shuffle step, with the position it was parsed
+	// from for error reporting
+	Technique struct {
+		Kind int
+		N    int64
+		Pos  parse.Pos
+	}
+)
+
+func (t Technique) String() string {
+	switch t.Kind {
+	case techNewStack:
+		return "deal into new stack"
+	case techCut:
+		return "cut " + strconv.FormatInt(t.N, 10)
+	case techIncr:
+		return "deal with increment " + strconv.FormatInt(t.N, 10)
+	default:
+		return "unknown technique"
+	}
+}
+
+func expectWords(line parse.Field, words []parse.Field, expected ...string) error {
+	for n, i := range expected {
+		if n >= len(words) {
+			end := line
+			if len(words) > 0 {
+				end = words[len(words)-1]
+			}
+			return end.EndErrorf("%w: expected %q", ErrTechnique, i)
+		}
+		if words[n].Text != i {
+			return words[n].Errorf("%w: expected %q, found %q", ErrTechnique, i, words[n].Text)
+		}
+	}
+	return nil
+}
+
+// ParseTechnique parses one line of a shuffle file
+func ParseTechnique(line parse.Field) (Technique, error) {
+	words := line.Words()
+	if len(words) == 0 {
+		return Technique{}, line.Errorf("%w: empty line", ErrTechnique)
+	}
+	t := Technique{
+		Pos: words[0].Pos,
+	}
+	numArg := 0
+	switch {
+	case words[0].Text == "cut":
+		t.Kind = techCut
+		numArg = 1
+	case len(words) > 1 && words[0].Text == "deal" && words[1].Text == "into":
+		if err := expectWords(line, words, "deal", "into", "new", "stack"); err != nil {
+			return Technique{}, err
+		}
+		t.Kind = techNewStack
+		numArg = 4
+	case words[0].Text == "deal":
+		if err := expectWords(line, words, "deal", "with", "increment"); err != nil {
+			return Technique{}, err
+		}
+		t.Kind = techIncr
+		numArg = 3
+	default:
+		return Technique{}, words[0].Errorf("%w: unknown technique %q", ErrTechnique, words[0].Text)
+	}
+	if t.Kind != techNewStack {
+		if numArg >= len(words) {
+			return Technique{}, words[len(words)-1].EndErrorf("%w: expected a number", ErrTechnique)
+		}
+		num, err := words[numArg].Int64()
+		if err != nil {
+			return Technique{}, words[numArg].Errorf("%w: expected a number, found %q", ErrTechnique, words[numArg].Text)
+		}
+		t.N = num
+		numArg++
+	}
+	if numArg < len(words) {
+		return Technique{}, words[numArg].Errorf("%w: unexpected %q", ErrTechnique, words[numArg].Text)
+	}
+	return t, nil
+}
+
+// ParseTechniques parses a shuffle file, skipping blank lines
+func ParseTechniques(r io.Reader) ([]Technique, error) {
+	lines, err := parse.NonBlank(r)
+	if err != nil {
+		return nil, err
+	}
+	techs := make([]Technique, 0, len(lines))
+	for _, i := range lines {
+		t, err := ParseTechnique(i)
+		if err != nil {
+			return nil, err
+		}
+		techs = append(techs, t)
+	}
+	return techs, nil
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// ValidateTechniques checks that every technique is a permutation of a deck
+// of the given size
+func ValidateTechniques(techs []Technique, size int64) error {
+	for _, t := range techs {
+		if t.Kind != techIncr {
+			continue
+		}
+		if t.N <= 0 {
+			return &parse.Error{
+				Pos: t.Pos,
+				Err: fmt.Errorf("%w: increment %d is not positive", ErrTechnique, t.N),
+			}
+		}
+		if g := gcd(t.N, size); g != 1 {
+			return &parse.Error{
+				Pos: t.Pos,
+				Err: fmt.Errorf("%w: increment %d shares factor %d with deck size %d", ErrTechnique, t.N, g, size),
+			}
+		}
+	}
+	return nil
+}
+
+func (t Technique) Apply(c *Card) {
+	switch t.Kind {
+	case techNewStack:
+		c.Reverse()
+	case techCut:
+		c.Cut(t.N)
+	case techIncr:
+		c.DealIncr(t.N)
+	}
+}
+
+func NewCardFrom(techs []Technique, size int64) *Card {
+	c := NewCard(size)
+	for _, t := range techs {
+		t.Apply(c)
+	}
+	return c
+}
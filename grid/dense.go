@@ -0,0 +1,182 @@
+package grid
+
+import (
+	"strings"
+)
+
+type (
+	// Dense is a rectangular grid of byte cells, such as a character map.
+	// Rows shorter than the widest row are padded with the fill cell.
+	Dense struct {
+		w, h  int
+		fill  byte
+		cells []byte
+	}
+)
+
+func NewDense(w, h int, fill byte) *Dense {
+	cells := make([]byte, w*h)
+	for i := range cells {
+		cells[i] = fill
+	}
+	return &Dense{
+		w:     w,
+		h:     h,
+		fill:  fill,
+		cells: cells,
+	}
+}
+
+// FromLines builds a grid from rows of cells, padding short rows with fill
+func FromLines(lines [][]byte, fill byte) *Dense {
+	w := 0
+	for _, i := range lines {
+		if len(i) > w {
+			w = len(i)
+		}
+	}
+	g := NewDense(w, len(lines), fill)
+	for y, i := range lines {
+		copy(g.cells[y*w:], i)
+	}
+	return g
+}
+
+// ParseString builds a grid from a literal with one row per line, dropping
+// trailing blank lines. Inputs are read with parse.Grid, which locates any
+// invalid cell.
+func ParseString(s string, fill byte) *Dense {
+	lines := [][]byte{}
+	for _, i := range strings.Split(s, "\n") {
+		lines = append(lines, []byte(i))
+	}
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return FromLines(lines, fill)
+}
+
+func (g *Dense) Width() int {
+	return g.w
+}
+
+func (g *Dense) Height() int {
+	return g.h
+}
+
+func (g *Dense) Bounds() Rect {
+	return Rect{Point{0, 0}, Point{g.w - 1, g.h - 1}}
+}
+
+func (g *Dense) InBounds(p Point) bool {
+	return p.X >= 0 && p.X < g.w && p.Y >= 0 && p.Y < g.h
+}
+
+// At returns the cell at a point, or the fill cell out of bounds
+func (g *Dense) At(p Point) byte {
+	if !g.InBounds(p) {
+		return g.fill
+	}
+	return g.cells[p.Y*g.w+p.X]
+}
+
+// Set sets the cell at a point, returning false if it is out of bounds
+func (g *Dense) Set(p Point, c byte) bool {
+	if !g.InBounds(p) {
+		return false
+	}
+	g.cells[p.Y*g.w+p.X] = c
+	return true
+}
+
+// Row returns a row of the grid, which aliases the grid's cells
+func (g *Dense) Row(y int) []byte {
+	return g.cells[y*g.w : (y+1)*g.w]
+}
+
+func (g *Dense) Clone() *Dense {
+	cells := make([]byte, len(g.cells))
+	copy(cells, g.cells)
+	return &Dense{
+		w:     g.w,
+		h:     g.h,
+		fill:  g.fill,
+		cells: cells,
+	}
+}
+
+// Find returns every point holding a cell, in reading order
+func (g *Dense) Find(c byte) []Point {
+	points := []Point{}
+	for n, i := range g.cells {
+		if i == c {
+			points = append(points, Point{n % g.w, n / g.w})
+		}
+	}
+	return points
+}
+
+// FindFunc returns every point whose cell matches, in reading order
+func (g *Dense) FindFunc(match func(c byte) bool) []Point {
+	points := []Point{}
+	for n, i := range g.cells {
+		if match(i) {
+			points = append(points, Point{n % g.w, n / g.w})
+		}
+	}
+	return points
+}
+
+// Neighbors4 returns the orthogonal neighbors in bounds whose cells match
+func (g *Dense) Neighbors4(p Point, match func(c byte) bool) []Point {
+	n := make([]Point, 0, 4)
+	for _, i := range deltas {
+		k := p.Add(i)
+		if g.InBounds(k) && (match == nil || match(g.At(k))) {
+			n = append(n, k)
+		}
+	}
+	return n
+}
+
+// Neighbors8 returns the surrounding points in bounds whose cells match
+func (g *Dense) Neighbors8(p Point, match func(c byte) bool) []Point {
+	n := make([]Point, 0, 8)
+	for _, i := range Offsets8 {
+		k := p.Add(i)
+		if g.InBounds(k) && (match == nil || match(g.At(k))) {
+			n = append(n, k)
+		}
+	}
+	return n
+}
+
+// FloodFill returns the orthogonal walking distance from start to every
+// point reachable through matching cells
+func (g *Dense) FloodFill(start Point, match func(c byte) bool) map[Point]int {
+	dist := map[Point]int{start: 0}
+	queue := []Point{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, k := range g.Neighbors4(cur, match) {
+			if _, ok := dist[k]; ok {
+				continue
+			}
+			dist[k] = dist[cur] + 1
+			queue = append(queue, k)
+		}
+	}
+	return dist
+}
+
+// String formats the grid with one row per line, the inverse of Parse
+func (g *Dense) String() string {
+	b := strings.Builder{}
+	b.Grow((g.w + 1) * g.h)
+	for y := 0; y < g.h; y++ {
+		b.Write(g.Row(y))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,204 @@
+package grid
+
+import (
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	for _, d := range Dirs {
+		if d.Left().Right() != d {
+			t.Errorf("%s: left then right = %s", d, d.Left().Right())
+		}
+		if d.Right().Right() != d.Reverse() {
+			t.Errorf("%s: two rights = %s, reverse = %s", d, d.Right().Right(), d.Reverse())
+		}
+		if d.Rotate(-5) != d.Left() {
+			t.Errorf("%s: rotate -5 = %s, want %s", d, d.Rotate(-5), d.Left())
+		}
+		if p := (Point{}).Step(d, 1).Add(d.Reverse().Delta()); p != (Point{}) {
+			t.Errorf("%s: step and back = %v", d, p)
+		}
+	}
+	if Up.Right() != Right || Right.Right() != Down || Down.Right() != Left || Left.Right() != Up {
+		t.Error("directions are not clockwise")
+	}
+	if p := (Point{2, 3}).Step(Up, 3); p != (Point{2, 0}) {
+		t.Errorf("up is not decreasing y: %v", p)
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	p := Point{1, 1}
+	n4 := p.Neighbors4()
+	if len(n4) != 4 {
+		t.Fatalf("4-neighborhood has %d points", len(n4))
+	}
+	n8 := p.Neighbors8()
+	if len(n8) != 8 {
+		t.Fatalf("8-neighborhood has %d points", len(n8))
+	}
+	seen := map[Point]bool{}
+	for _, i := range n8 {
+		if seen[i] || i == p {
+			t.Errorf("bad 8-neighbor %v", i)
+		}
+		seen[i] = true
+		if d := i.Sub(p); d.X < -1 || d.X > 1 || d.Y < -1 || d.Y > 1 {
+			t.Errorf("8-neighbor %v is not adjacent", i)
+		}
+	}
+	for _, i := range n4 {
+		if !seen[i] || p.Manhattan(i) != 1 {
+			t.Errorf("bad 4-neighbor %v", i)
+		}
+	}
+}
+
+func TestBounds(t *testing.T) {
+	if _, ok := Bounds(nil); ok {
+		t.Error("bounds of no points")
+	}
+	r, ok := Bounds([]Point{{3, -1}, {-2, 4}, {0, 0}})
+	if !ok {
+		t.Fatal("no bounds")
+	}
+	if r != (Rect{Point{-2, -1}, Point{3, 4}}) {
+		t.Errorf("bounds = %v", r)
+	}
+	if r.Width() != 6 || r.Height() != 6 {
+		t.Errorf("size = %dx%d", r.Width(), r.Height())
+	}
+	for _, c := range []struct {
+		p            Point
+		contains, on bool
+	}{
+		{Point{0, 0}, true, false},
+		{Point{-2, 0}, true, true},
+		{Point{3, 4}, true, true},
+		{Point{4, 0}, false, false},
+	} {
+		if r.Contains(c.p) != c.contains || r.OnEdge(c.p) != c.on {
+			t.Errorf("%v: contains %t, on edge %t", c.p, r.Contains(c.p), r.OnEdge(c.p))
+		}
+	}
+}
+
+const maze = `#########
+#b.A.@.a#
+#########
+`
+
+func TestDenseRoundTrip(t *testing.T) {
+	g := ParseString(maze+"\n", '#')
+	if g.Width() != 9 || g.Height() != 3 {
+		t.Fatalf("size = %dx%d", g.Width(), g.Height())
+	}
+	if s := g.String(); s != maze {
+		t.Errorf("round trip:\n%s", s)
+	}
+	if c := g.At(Point{5, 1}); c != '@' {
+		t.Errorf("at = %c", c)
+	}
+	if c := g.At(Point{-1, 1}); c != '#' {
+		t.Errorf("out of bounds = %c", c)
+	}
+	if g.Set(Point{9, 0}, '.') {
+		t.Error("set out of bounds")
+	}
+
+	ragged := ParseString("ab\na\n", ' ')
+	if s := ragged.String(); s != "ab\na \n" {
+		t.Errorf("ragged rows are not padded: %q", s)
+	}
+}
+
+func TestDenseFind(t *testing.T) {
+	g := ParseString(maze, '#')
+	if p := g.Find('@'); len(p) != 1 || p[0] != (Point{5, 1}) {
+		t.Errorf("find = %v", p)
+	}
+	keys := g.FindFunc(func(c byte) bool {
+		return c >= 'a' && c <= 'z'
+	})
+	if len(keys) != 2 || keys[0] != (Point{1, 1}) || keys[1] != (Point{7, 1}) {
+		t.Errorf("find func = %v", keys)
+	}
+	if n := g.Neighbors4(Point{5, 1}, func(c byte) bool { return c != '#' }); len(n) != 2 {
+		t.Errorf("open neighbors = %v", n)
+	}
+	if n := g.Neighbors8(Point{0, 0}, nil); len(n) != 3 {
+		t.Errorf("corner neighbors = %v", n)
+	}
+	c := g.Clone()
+	c.Set(Point{5, 1}, '.')
+	if g.At(Point{5, 1}) != '@' {
+		t.Error("clone shares cells")
+	}
+}
+
+func TestDenseFloodFill(t *testing.T) {
+	g := ParseString(maze, '#')
+	dist := g.FloodFill(Point{5, 1}, func(c byte) bool {
+		return c != '#' && c != 'A'
+	})
+	if len(dist) != 4 {
+		t.Errorf("filled %d tiles", len(dist))
+	}
+	if d, ok := dist[Point{7, 1}]; !ok || d != 2 {
+		t.Errorf("dist to a = %d, %t", d, ok)
+	}
+	if _, ok := dist[Point{1, 1}]; ok {
+		t.Error("fill passed through the door")
+	}
+}
+
+func TestSparse(t *testing.T) {
+	g := NewSparse()
+	if _, ok := g.Bounds(); ok {
+		t.Error("bounds of an empty grid")
+	}
+	if s := g.Format(nil, ' '); s != "" {
+		t.Errorf("empty format = %q", s)
+	}
+	g.Set(Point{-1, -1}, 1)
+	g.Set(Point{0, -1}, 1)
+	g.Set(Point{1, 0}, 2)
+	if r, _ := g.Bounds(); r != (Rect{Point{-1, -1}, Point{1, 0}}) {
+		t.Errorf("bounds = %v", r)
+	}
+	if v, ok := g.At(Point{0, 0}); ok {
+		t.Errorf("unset cell = %d", v)
+	}
+	if v := g.Get(Point{0, 0}, 7); v != 7 {
+		t.Errorf("default = %d", v)
+	}
+	if g.Len() != 3 || len(g.Points()) != 3 || len(g.Find(1)) != 2 {
+		t.Errorf("len = %d, find = %v", g.Len(), g.Find(1))
+	}
+	palette := func(v int) byte {
+		return "_#o"[v]
+	}
+	if s := g.Format(palette, '.'); s != "##.\n..o\n" {
+		t.Errorf("format = %q", s)
+	}
+	if d := g.FloodFill(Point{-1, -1}, func(v int) bool { return v == 1 }); len(d) != 2 || d[Point{0, -1}] != 1 {
+		t.Errorf("flood fill = %v", d)
+	}
+}
+
+func TestSparseFromDense(t *testing.T) {
+	d := ParseString(maze, '#')
+	g := SparseFromDense(d, func(c byte) (int, bool) {
+		if c == '#' {
+			return 0, false
+		}
+		return int(c), true
+	})
+	if g.Len() != 7 {
+		t.Errorf("len = %d", g.Len())
+	}
+	s := g.Format(func(v int) byte { return byte(v) }, '#')
+	if s != "b.A.@.a\n" {
+		t.Errorf("format = %q", s)
+	}
+}
@@ -0,0 +1,159 @@
+package grid
+
+type (
+	// Point is a position on a grid, where y increases downwards
+	Point struct {
+		X, Y int
+	}
+
+	// Dir is a direction on a grid, numbered clockwise from up
+	Dir int
+
+	// Rect is a bounding box, inclusive of both corners
+	Rect struct {
+		Min, Max Point
+	}
+)
+
+const (
+	Up Dir = iota
+	Right
+	Down
+	Left
+)
+
+var (
+	// Dirs are the four directions in clockwise order from up
+	Dirs = [4]Dir{Up, Right, Down, Left}
+
+	deltas = [4]Point{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+	// Offsets8 are the offsets to the eight surrounding points, clockwise
+	// from up
+	Offsets8 = [8]Point{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+)
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{p.X - q.X, p.Y - q.Y}
+}
+
+// Step returns the point n steps away in a direction
+func (p Point) Step(d Dir, n int) Point {
+	k := d.Delta()
+	return Point{p.X + k.X*n, p.Y + k.Y*n}
+}
+
+func (p Point) Manhattan(q Point) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y)
+}
+
+// Neighbors4 returns the four orthogonal neighbors, clockwise from up
+func (p Point) Neighbors4() []Point {
+	n := make([]Point, 0, 4)
+	for _, i := range deltas {
+		n = append(n, p.Add(i))
+	}
+	return n
+}
+
+// Neighbors8 returns the eight surrounding points, clockwise from up
+func (p Point) Neighbors8() []Point {
+	n := make([]Point, 0, 8)
+	for _, i := range Offsets8 {
+		n = append(n, p.Add(i))
+	}
+	return n
+}
+
+func (d Dir) Delta() Point {
+	return deltas[d.Rotate(0)]
+}
+
+// Rotate turns the direction clockwise by a number of quarter turns, which
+// may be negative
+func (d Dir) Rotate(quarters int) Dir {
+	return Dir(((int(d)+quarters)%4 + 4) % 4)
+}
+
+func (d Dir) Left() Dir {
+	return d.Rotate(-1)
+}
+
+func (d Dir) Right() Dir {
+	return d.Rotate(1)
+}
+
+func (d Dir) Reverse() Dir {
+	return d.Rotate(2)
+}
+
+func (d Dir) String() string {
+	switch d.Rotate(0) {
+	case Up:
+		return "up"
+	case Right:
+		return "right"
+	case Down:
+		return "down"
+	default:
+		return "left"
+	}
+}
+
+// Bounds returns the smallest rect containing every point, and false if
+// there are none
+func Bounds(points []Point) (Rect, bool) {
+	if len(points) == 0 {
+		return Rect{}, false
+	}
+	r := Rect{points[0], points[0]}
+	for _, i := range points[1:] {
+		r = r.Extend(i)
+	}
+	return r, true
+}
+
+// Extend grows the rect to contain a point
+func (r Rect) Extend(p Point) Rect {
+	if p.X < r.Min.X {
+		r.Min.X = p.X
+	}
+	if p.Y < r.Min.Y {
+		r.Min.Y = p.Y
+	}
+	if p.X > r.Max.X {
+		r.Max.X = p.X
+	}
+	if p.Y > r.Max.Y {
+		r.Max.Y = p.Y
+	}
+	return r
+}
+
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.Min.X && p.X <= r.Max.X && p.Y >= r.Min.Y && p.Y <= r.Max.Y
+}
+
+// OnEdge reports whether a point lies on the border of the rect
+func (r Rect) OnEdge(p Point) bool {
+	return r.Contains(p) && (p.X == r.Min.X || p.X == r.Max.X || p.Y == r.Min.Y || p.Y == r.Max.Y)
+}
+
+func (r Rect) Width() int {
+	return r.Max.X - r.Min.X + 1
+}
+
+func (r Rect) Height() int {
+	return r.Max.Y - r.Min.Y + 1
+}
@@ -0,0 +1,133 @@
+package grid
+
+type (
+	// Sparse is an unbounded grid of int cells, such as the tiles discovered
+	// by a robot, which tracks the bounding box of every cell set
+	Sparse struct {
+		cells  map[Point]int
+		bounds Rect
+	}
+)
+
+func NewSparse() *Sparse {
+	return &Sparse{
+		cells: map[Point]int{},
+	}
+}
+
+// At returns the cell at a point, and false if it has never been set
+func (g *Sparse) At(p Point) (int, bool) {
+	v, ok := g.cells[p]
+	return v, ok
+}
+
+// Get returns the cell at a point, or def if it has never been set
+func (g *Sparse) Get(p Point, def int) int {
+	if v, ok := g.cells[p]; ok {
+		return v
+	}
+	return def
+}
+
+func (g *Sparse) Set(p Point, v int) {
+	if len(g.cells) == 0 {
+		g.bounds = Rect{p, p}
+	} else {
+		g.bounds = g.bounds.Extend(p)
+	}
+	g.cells[p] = v
+}
+
+func (g *Sparse) Len() int {
+	return len(g.cells)
+}
+
+// Bounds returns the bounding box of every cell set, and false if none are
+func (g *Sparse) Bounds() (Rect, bool) {
+	return g.bounds, len(g.cells) > 0
+}
+
+// Points returns every point that has been set, in no particular order
+func (g *Sparse) Points() []Point {
+	points := make([]Point, 0, len(g.cells))
+	for k := range g.cells {
+		points = append(points, k)
+	}
+	return points
+}
+
+// Find returns every point holding a value, in no particular order
+func (g *Sparse) Find(v int) []Point {
+	points := []Point{}
+	for k, i := range g.cells {
+		if i == v {
+			points = append(points, k)
+		}
+	}
+	return points
+}
+
+// Neighbors4 returns the orthogonal neighbors that have been set and whose
+// cells match
+func (g *Sparse) Neighbors4(p Point, match func(v int) bool) []Point {
+	n := make([]Point, 0, 4)
+	for _, i := range deltas {
+		k := p.Add(i)
+		if v, ok := g.cells[k]; ok && (match == nil || match(v)) {
+			n = append(n, k)
+		}
+	}
+	return n
+}
+
+// FloodFill returns the orthogonal walking distance from start to every
+// point reachable through matching cells
+func (g *Sparse) FloodFill(start Point, match func(v int) bool) map[Point]int {
+	dist := map[Point]int{start: 0}
+	queue := []Point{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, k := range g.Neighbors4(cur, match) {
+			if _, ok := dist[k]; ok {
+				continue
+			}
+			dist[k] = dist[cur] + 1
+			queue = append(queue, k)
+		}
+	}
+	return dist
+}
+
+// Dense converts the cells within the bounding box to bytes, with unset
+// cells as def
+func (g *Sparse) Dense(palette func(v int) byte, def byte) *Dense {
+	r, ok := g.Bounds()
+	if !ok {
+		return NewDense(0, 0, def)
+	}
+	d := NewDense(r.Width(), r.Height(), def)
+	for k, v := range g.cells {
+		d.Set(k.Sub(r.Min), palette(v))
+	}
+	return d
+}
+
+// Format renders the cells within the bounding box with one row per line
+func (g *Sparse) Format(palette func(v int) byte, def byte) string {
+	return g.Dense(palette, def).String()
+}
+
+// SparseFromDense sets a cell for every point of a dense grid whose cell
+// maps to a value
+func SparseFromDense(d *Dense, value func(c byte) (int, bool)) *Sparse {
+	g := NewSparse()
+	for y := 0; y < d.h; y++ {
+		for x, c := range d.Row(y) {
+			if v, ok := value(c); ok {
+				g.Set(Point{x, y}, v)
+			}
+		}
+	}
+	return g
+}
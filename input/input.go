@@ -0,0 +1,179 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+const (
+	DefaultBaseURL = "https://adventofcode.com"
+	year           = 2019
+	inputFile      = "input.txt"
+	paramsFile     = "params.txt"
+	answersFile    = "answers.txt"
+	// DefaultTimeout bounds a whole download by NewFetcher, so that a stalled
+	// connection cannot hang the runner
+	DefaultTimeout = 30 * time.Second
+)
+
+var (
+	ErrNotFound = errors.New("input not found")
+	ErrFetch    = errors.New("failed to fetch input")
+)
+
+type (
+	// Layout locates the files of each day, in dayNN by default, or in
+	// Root/User/dayNN for the inputs of a given user
+	Layout struct {
+		Root string
+		User string
+	}
+
+	// Doer sends http requests, such as an *http.Client
+	Doer interface {
+		Do(req *http.Request) (*http.Response, error)
+	}
+
+	// Fetcher downloads inputs from the puzzle site, authenticated by a
+	// session cookie
+	Fetcher struct {
+		Client  Doer
+		BaseURL string
+		Session string
+	}
+
+	// Source reads inputs from a Layout, fetching and storing any missing
+	// input when it has a Fetcher
+	Source struct {
+		Layout  Layout
+		Fetcher *Fetcher
+	}
+)
+
+func DayDir(day int) string {
+	return fmt.Sprintf("day%02d", day)
+}
+
+// Dir is the directory of the files of a day
+func (l Layout) Dir(day int) string {
+	if l.User == "" {
+		return filepath.Join(l.Root, DayDir(day))
+	}
+	return filepath.Join(l.Root, l.User, DayDir(day))
+}
+
+func (l Layout) Input(day int) string {
+	return filepath.Join(l.Dir(day), inputFile)
+}
+
+func (l Layout) Params(day int) string {
+	return filepath.Join(l.Dir(day), paramsFile)
+}
+
+func (l Layout) Answers(day int) string {
+	return filepath.Join(l.Dir(day), answersFile)
+}
+
+// NewFetcher creates a Fetcher using an http client with DefaultTimeout
+func NewFetcher(session string) *Fetcher {
+	return &Fetcher{
+		Client: &http.Client{
+			Timeout: DefaultTimeout,
+		},
+		BaseURL: DefaultBaseURL,
+		Session: session,
+	}
+}
+
+// Fetch downloads the input of a day
+func (f *Fetcher) Fetch(day int) ([]byte, error) {
+	url := fmt.Sprintf("%s/%d/day/%d/input", strings.TrimRight(f.BaseURL, "/"), year, day)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{
+		Name:  "session",
+		Value: f.Session,
+	})
+	res, err := f.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrFetch, err)
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if cerr := res.Body.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrFetch, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: day %d: %s", ErrFetch, day, res.Status)
+	}
+	return b, nil
+}
+
+// Read reads the input of a day. A missing input is fetched, when the
+// Source has a Fetcher, and stored in the layout so that it is only
+// downloaded once.
+func (s Source) Read(day int) ([]byte, error) {
+	path := s.Layout.Input(day)
+	b, err := ioutil.ReadFile(path)
+	if err == nil {
+		return b, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+	if s.Fetcher == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, path)
+	}
+	b, err = s.Fetcher.Fetch(day)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(path, b, 0644); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Params reads the params of a day, which are empty when there is no params
+// file
+func (s Source) Params(day int) (aoc.Params, error) {
+	path := s.Layout.Params(day)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return aoc.Params{}, nil
+		}
+		return nil, err
+	}
+	return aoc.ParseParams(parse.Named(path, bytes.NewReader(b)))
+}
+
+// Solver applies the params of a day to its solver, if it takes any
+func (s Source) Solver(day int, solver aoc.Solver) (aoc.Solver, error) {
+	ps, ok := solver.(aoc.ParamSolver)
+	if !ok {
+		return solver, nil
+	}
+	p, err := s.Params(day)
+	if err != nil {
+		return nil, err
+	}
+	return ps.WithParams(p)
+}
@@ -0,0 +1,180 @@
+package input
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/day02"
+)
+
+func tempDir(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "advent2019-input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLayout(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		layout   Layout
+		expected string
+	}{
+		{name: "default", layout: Layout{}, expected: filepath.Join("day04", "input.txt")},
+		{name: "user", layout: Layout{Root: "inputs", User: "alice"}, expected: filepath.Join("inputs", "alice", "day04", "input.txt")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if path := tc.layout.Input(4); path != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, path)
+			}
+		})
+	}
+}
+
+// stub serves a fixed input for day 1 to requests with the session cookie,
+// counting the requests
+type stub struct {
+	requests int
+}
+
+func (s *stub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests++
+	c, err := r.Cookie("session")
+	if err != nil || c.Value != "secret" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.URL.Path != "/2019/day/1/input" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Write([]byte("12\n14\n"))
+}
+
+func TestSourceFetch(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	s := &stub{}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	src := Source{
+		Layout: Layout{Root: dir, User: "alice"},
+		Fetcher: &Fetcher{
+			Client:  server.Client(),
+			BaseURL: server.URL,
+			Session: "secret",
+		},
+	}
+	for i := 0; i < 2; i++ {
+		b, err := src.Read(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != "12\n14\n" {
+			t.Fatalf("expected %q, got %q", "12\n14\n", b)
+		}
+	}
+	if s.requests != 1 {
+		t.Fatalf("expected 1 request, got %d", s.requests)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "alice", "day01", "input.txt")); err != nil {
+		t.Fatalf("input not cached: %v", err)
+	}
+
+	if _, err := src.Read(2); !errors.Is(err, ErrFetch) {
+		t.Fatalf("expected %v, got %v", ErrFetch, err)
+	}
+	src.Fetcher.Session = "wrong"
+	if _, err := src.Read(3); !errors.Is(err, ErrFetch) {
+		t.Fatalf("expected %v, got %v", ErrFetch, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "alice", "day03")); !os.IsNotExist(err) {
+		t.Fatalf("failed fetch was cached: %v", err)
+	}
+}
+
+func TestFetchTimeout(t *testing.T) {
+	if c, ok := NewFetcher("secret").Client.(*http.Client); !ok || c.Timeout != DefaultTimeout {
+		t.Fatalf("expected a client with a timeout of %s, got %+v", DefaultTimeout, c)
+	}
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	client := server.Client()
+	client.Timeout = 50 * time.Millisecond
+	f := &Fetcher{
+		Client:  client,
+		BaseURL: server.URL,
+		Session: "secret",
+	}
+	if _, err := f.Fetch(1); !errors.Is(err, ErrFetch) {
+		t.Fatalf("expected %v, got %v", ErrFetch, err)
+	}
+}
+
+func TestSourceNotFound(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	src := Source{
+		Layout: Layout{Root: dir, User: "alice"},
+	}
+	if _, err := src.Read(1); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected %v, got %v", ErrNotFound, err)
+	}
+}
+
+func TestSourceSolver(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	src := Source{
+		Layout: Layout{Root: dir, User: "alice"},
+	}
+	var solver aoc.Solver = day02.Solver{Target: day02.DefaultTarget}
+
+	s, err := src.Solver(2, solver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target := s.(day02.Solver).Target; target != day02.DefaultTarget {
+		t.Fatalf("expected %d, got %d", day02.DefaultTarget, target)
+	}
+
+	if err := os.MkdirAll(src.Layout.Dir(2), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(src.Layout.Params(2), []byte("target = 1234\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err = src.Solver(2, solver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target := s.(day02.Solver).Target; target != 1234 {
+		t.Fatalf("expected %d, got %d", 1234, target)
+	}
+
+	if err := ioutil.WriteFile(src.Layout.Params(2), []byte("target = x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = src.Solver(2, solver)
+	if !errors.Is(err, aoc.ErrParams) {
+		t.Fatalf("expected %v, got %v", aoc.ErrParams, err)
+	}
+	if pos := src.Layout.Params(2) + ":1:"; !strings.HasPrefix(err.Error(), pos) {
+		t.Fatalf("expected error at %s, got %v", pos, err)
+	}
+}
@@ -0,0 +1,283 @@
+// Package intcode runs intcode programs one instruction at a time, so that
+// a machine needs no goroutine of its own and reports every fault as an error
+package intcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrIllegalOp   = errors.New("illegal op code")
+	ErrIllegalMode = errors.New("illegal param mode")
+	ErrMemBounds   = errors.New("memory access out of bounds")
+	ErrNoInput     = errors.New("machine is waiting for input")
+)
+
+const (
+	modePos = iota
+	modeImm
+	modeRel
+)
+
+type (
+	// Machine runs a program in place in its memory. It only runs while its
+	// output is being read or while it is executed to a halt.
+	Machine struct {
+		pc      int
+		relBase int
+		mem     []int
+		inp     []int
+		out     []int
+		input   func() int
+		halted  bool
+		err     error
+	}
+)
+
+// NewMachine runs the program in mem, which is also all of its memory
+func NewMachine(mem []int) *Machine {
+	return &Machine{
+		pc:      0,
+		relBase: 0,
+		mem:     mem,
+		inp:     nil,
+		out:     nil,
+		input:   nil,
+		halted:  false,
+		err:     nil,
+	}
+}
+
+// Reset loads a program, clearing the rest of memory, and restarts the
+// machine without reallocating its memory
+func (m *Machine) Reset(prog []int) {
+	n := copy(m.mem, prog)
+	for i := n; i < len(m.mem); i++ {
+		m.mem[i] = 0
+	}
+	m.pc = 0
+	m.relBase = 0
+	m.inp = m.inp[:0]
+	m.out = m.out[:0]
+	m.halted = false
+	m.err = nil
+}
+
+// SetInput sets a function which supplies input once the written inputs run
+// out
+func (m *Machine) SetInput(f func() int) {
+	m.input = f
+}
+
+func decodeOp(code int) (int, int, int, int) {
+	return code % 100, code / 100 % 10, code / 1000 % 10, code / 10000 % 10
+}
+
+// MemAt returns the value at an address
+func (m *Machine) MemAt(pos int) (int, error) {
+	if pos < 0 || pos >= len(m.mem) {
+		return 0, fmt.Errorf("%w: read %d", ErrMemBounds, pos)
+	}
+	return m.mem[pos], nil
+}
+
+// MemSet sets the value at an address
+func (m *Machine) MemSet(pos, val int) error {
+	if pos < 0 || pos >= len(m.mem) {
+		return fmt.Errorf("%w: write %d", ErrMemBounds, pos)
+	}
+	m.mem[pos] = val
+	return nil
+}
+
+func (m *Machine) getArg(mode, offset int) (int, error) {
+	arg, err := m.MemAt(m.pc + offset)
+	if err != nil {
+		return 0, err
+	}
+	switch mode {
+	case modePos:
+		return m.MemAt(arg)
+	case modeImm:
+		return arg, nil
+	case modeRel:
+		return m.MemAt(arg + m.relBase)
+	default:
+		return 0, fmt.Errorf("%w: %d at %d", ErrIllegalMode, mode, m.pc)
+	}
+}
+
+func (m *Machine) setArg(mode, offset, val int) error {
+	arg, err := m.MemAt(m.pc + offset)
+	if err != nil {
+		return err
+	}
+	switch mode {
+	case modePos:
+		return m.MemSet(arg, val)
+	case modeRel:
+		return m.MemSet(arg+m.relBase, val)
+	default:
+		return fmt.Errorf("%w: write %d at %d", ErrIllegalMode, mode, m.pc)
+	}
+}
+
+func (m *Machine) getArgs2(a1, a2 int) (int, int, error) {
+	x, err := m.getArg(a1, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	y, err := m.getArg(a2, 2)
+	if err != nil {
+		return 0, 0, err
+	}
+	return x, y, nil
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Write queues an input for the machine
+func (m *Machine) Write(inp int) {
+	m.inp = append(m.inp, inp)
+}
+
+func (m *Machine) recvInput() (int, error) {
+	if len(m.inp) > 0 {
+		v := m.inp[0]
+		m.inp = m.inp[1:]
+		return v, nil
+	}
+	if m.input != nil {
+		return m.input(), nil
+	}
+	return 0, fmt.Errorf("%w at %d", ErrNoInput, m.pc)
+}
+
+// Read runs the machine until it outputs a value, and returns it. It returns
+// false once the machine halts, fails, or waits for input that has not been
+// written, and Err reports why it failed.
+func (m *Machine) Read() (int, bool) {
+	for len(m.out) == 0 {
+		if m.halted || m.err != nil {
+			return 0, false
+		}
+		ok, err := m.Exec()
+		if err != nil {
+			m.err = err
+			return 0, false
+		}
+		m.halted = !ok
+	}
+	v := m.out[0]
+	m.out = m.out[1:]
+	return v, true
+}
+
+// Err returns the error that stopped the machine, if any
+func (m *Machine) Err() error {
+	return m.err
+}
+
+// Exec runs a single instruction, returning false once the machine halts
+func (m *Machine) Exec() (bool, error) {
+	code, err := m.MemAt(m.pc)
+	if err != nil {
+		return false, err
+	}
+	op, a1, a2, a3 := decodeOp(code)
+	switch op {
+	case 1, 2, 7, 8:
+		x, y, err := m.getArgs2(a1, a2)
+		if err != nil {
+			return false, err
+		}
+		var v int
+		switch op {
+		case 1:
+			v = x + y
+		case 2:
+			v = x * y
+		case 7:
+			v = boolInt(x < y)
+		case 8:
+			v = boolInt(x == y)
+		}
+		if err := m.setArg(a3, 3, v); err != nil {
+			return false, err
+		}
+		m.pc += 4
+	case 3:
+		v, err := m.recvInput()
+		if err != nil {
+			return false, err
+		}
+		if err := m.setArg(a1, 1, v); err != nil {
+			return false, err
+		}
+		m.pc += 2
+	case 4:
+		x, err := m.getArg(a1, 1)
+		if err != nil {
+			return false, err
+		}
+		m.out = append(m.out, x)
+		m.pc += 2
+	case 5, 6:
+		x, y, err := m.getArgs2(a1, a2)
+		if err != nil {
+			return false, err
+		}
+		if (x != 0) == (op == 5) {
+			m.pc = y
+		} else {
+			m.pc += 3
+		}
+	case 9:
+		x, err := m.getArg(a1, 1)
+		if err != nil {
+			return false, err
+		}
+		m.relBase += x
+		m.pc += 2
+	case 99:
+		m.pc++
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: %d at %d", ErrIllegalOp, code, m.pc)
+	}
+	return true, nil
+}
+
+// Execute runs the machine until it halts, keeping its outputs to be read
+func (m *Machine) Execute() error {
+	for !m.halted {
+		if m.err != nil {
+			return m.err
+		}
+		ok, err := m.Exec()
+		if err != nil {
+			m.err = err
+			return err
+		}
+		m.halted = !ok
+	}
+	return nil
+}
+
+// Outputs reads every output the machine has left, running it to a halt
+func (m *Machine) Outputs() ([]int, error) {
+	outs := []int{}
+	for {
+		v, ok := m.Read()
+		if !ok {
+			return outs, m.Err()
+		}
+		outs = append(outs, v)
+	}
+}
@@ -0,0 +1,145 @@
+package intcode
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestExecute(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		mem      []int
+		expected []int
+	}{
+		{name: "add", mem: []int{1, 0, 0, 0, 99}, expected: []int{2, 0, 0, 0, 99}},
+		{name: "mul", mem: []int{2, 3, 0, 3, 99}, expected: []int{2, 3, 0, 6, 99}},
+		{name: "immediate", mem: []int{1002, 4, 3, 4, 33}, expected: []int{1002, 4, 3, 4, 99}},
+		{name: "negative", mem: []int{1101, 100, -1, 4, 0}, expected: []int{1101, 100, -1, 4, 99}},
+		{name: "relative", mem: []int{109, 2, 22201, 0, 1, 3, 99}, expected: []int{109, 2, 22201, 0, 1, 22201, 99}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := NewMachine(tc.mem).Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tc.mem, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, tc.mem)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		mem      []int
+		expected error
+	}{
+		{name: "illegal op", mem: []int{42, 0, 0, 0, 99}, expected: ErrIllegalOp},
+		{name: "illegal mode", mem: []int{304, 1, 99}, expected: ErrIllegalMode},
+		{name: "immediate write", mem: []int{11101, 1, 1, 0, 99}, expected: ErrIllegalMode},
+		{name: "read out of bounds", mem: []int{1, 9, 0, 0, 99}, expected: ErrMemBounds},
+		{name: "write out of bounds", mem: []int{1, 0, 0, 9, 99}, expected: ErrMemBounds},
+		{name: "relative out of bounds", mem: []int{109, -3, 204, 0, 99}, expected: ErrMemBounds},
+		{name: "no halt", mem: []int{1, 0, 0, 0}, expected: ErrMemBounds},
+		{name: "no input", mem: []int{3, 0, 99}, expected: ErrNoInput},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewMachine(tc.mem)
+			if err := m.Execute(); !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+			if err := m.Err(); !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+			if _, ok := m.Read(); ok {
+				t.Fatal("expected no output after an error")
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	m := NewMachine([]int{3, 9, 4, 9, 4, 9, 99, 0, 0, 0})
+	m.Write(7)
+	for _, expected := range []int{7, 7} {
+		out, ok := m.Read()
+		if !ok {
+			t.Fatalf("expected output, got %v", m.Err())
+		}
+		if out != expected {
+			t.Fatalf("expected %d, got %d", expected, out)
+		}
+	}
+	if _, ok := m.Read(); ok {
+		t.Fatal("expected halt")
+	}
+	if err := m.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.Read(); ok {
+		t.Fatal("expected no output after halt")
+	}
+}
+
+func TestInput(t *testing.T) {
+	// echo outputs every input until it reads 0
+	echo := []int{3, 9, 4, 9, 1005, 9, 0, 99, 0, 0}
+	m := NewMachine(echo)
+	m.Write(5)
+	count := 0
+	m.SetInput(func() int {
+		count++
+		if count > 2 {
+			return 0
+		}
+		return count
+	})
+	outs, err := m.Outputs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []int{5, 1, 2, 0}; !reflect.DeepEqual(outs, expected) {
+		t.Fatalf("expected %v, got %v", expected, outs)
+	}
+}
+
+func TestReset(t *testing.T) {
+	// count increments a counter in its own memory and outputs it
+	count := []int{1001, 8, 1, 8, 4, 8, 99}
+	m := NewMachine(make([]int, 16))
+	for i := 0; i < 3; i++ {
+		m.Reset(count)
+		outs, err := m.Outputs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := []int{1}; !reflect.DeepEqual(outs, expected) {
+			t.Fatalf("expected %v, got %v", expected, outs)
+		}
+	}
+	m.Reset([]int{3, 0, 99})
+	if _, ok := m.Read(); ok || !errors.Is(m.Err(), ErrNoInput) {
+		t.Fatalf("expected %v, got %v", ErrNoInput, m.Err())
+	}
+	m.Reset(count)
+	if err := m.Err(); err != nil {
+		t.Fatalf("error not cleared by reset: %v", err)
+	}
+}
+
+func TestMem(t *testing.T) {
+	m := NewMachine(make([]int, 4))
+	if err := m.MemSet(3, 7); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := m.MemAt(3); err != nil || v != 7 {
+		t.Fatalf("expected 7, got %d, %v", v, err)
+	}
+	if err := m.MemSet(4, 7); !errors.Is(err, ErrMemBounds) {
+		t.Fatalf("expected %v, got %v", ErrMemBounds, err)
+	}
+	if _, err := m.MemAt(-1); !errors.Is(err, ErrMemBounds) {
+		t.Fatalf("expected %v, got %v", ErrMemBounds, err)
+	}
+}
@@ -0,0 +1,72 @@
+// Package rust finds and builds the Rust solutions kept alongside the Go ones
+package rust
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrNoCargo = errors.New("cargo not found")
+	ErrNoRust  = errors.New("no Rust solution")
+	ErrStub    = errors.New("Rust solution is unimplemented")
+)
+
+const (
+	// stub is the main.rs created by cargo new, which the days never solved
+	// in Rust still have
+	stub = "fn main() {\n    println!(\"Hello, world!\");\n}\n"
+)
+
+// LookCargo checks that cargo is installed
+func LookCargo() error {
+	if _, err := exec.LookPath("cargo"); err != nil {
+		return ErrNoCargo
+	}
+	return nil
+}
+
+// Check reports why the Rust solution in a day's directory cannot be run, if
+// it is missing or is only the stub from cargo new
+func Check(dir string) error {
+	if _, err := os.Stat(filepath.Join(dir, "Cargo.toml")); err != nil {
+		if os.IsNotExist(err) {
+			return ErrNoRust
+		}
+		return err
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, "src", "main.rs"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrNoRust
+		}
+		return err
+	}
+	if strings.Join(strings.Fields(string(b)), " ") == strings.Join(strings.Fields(stub), " ") {
+		return ErrStub
+	}
+	return nil
+}
+
+// Build builds the Rust solution in a day's directory without network
+// access, returning the path of the binary
+func Build(dir, target string) (string, error) {
+	cmd := exec.Command("cargo", "build", "--release", "--offline", "--quiet", "--target-dir", target)
+	cmd.Dir = dir
+	out := bytes.Buffer{}
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(out.String())
+		if k := strings.IndexByte(msg, '\n'); k >= 0 {
+			msg = msg[:k]
+		}
+		return "", fmt.Errorf("cargo build: %w: %s", err, msg)
+	}
+	return filepath.Abs(filepath.Join(target, "release", filepath.Base(dir)))
+}
@@ -0,0 +1,28 @@
+package rust
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/xorkevin/advent2019/input"
+)
+
+func TestCheck(t *testing.T) {
+	for _, tc := range []struct {
+		day      int
+		expected error
+	}{
+		{day: 1, expected: nil},
+		{day: 5, expected: nil},
+		{day: 8, expected: ErrStub},
+		{day: 13, expected: ErrStub},
+		{day: 14, expected: ErrNoRust},
+	} {
+		t.Run(input.DayDir(tc.day), func(t *testing.T) {
+			if err := Check(filepath.Join("..", "..", input.DayDir(tc.day))); !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package testutil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing/quick"
+
+	"github.com/xorkevin/advent2019/parse"
+)
+
+const (
+	fuzzName   = "fuzz.txt"
+	fuzzTokens = 64
+	fuzzCount  = 1000
+)
+
+// Fuzz checks a parser on random inputs made of up to 64 of the given tokens,
+// a deterministic sample of 1000 inputs. The parser must not panic, and every
+// error it returns must be a *parse.Error located within its input. A parser
+// which may fail for reasons other than the syntax of its input should
+// return nil for those errors.
+func Fuzz(tokens []string, parser func(r io.Reader) error) error {
+	var failure error
+	check := func(input string) bool {
+		failure = checkPos(input, parser(parse.Named(fuzzName, strings.NewReader(input))))
+		return failure == nil
+	}
+	config := &quick.Config{
+		MaxCount: fuzzCount,
+		Rand:     rand.New(rand.NewSource(1)),
+		Values: func(args []reflect.Value, rng *rand.Rand) {
+			b := strings.Builder{}
+			for i := rng.Intn(fuzzTokens + 1); i > 0; i-- {
+				b.WriteString(tokens[rng.Intn(len(tokens))])
+			}
+			args[0] = reflect.ValueOf(b.String())
+		},
+	}
+	if err := quick.Check(check, config); err != nil {
+		var cerr *quick.CheckError
+		if errors.As(err, &cerr) {
+			return fmt.Errorf("input %q: %w", cerr.In[0], failure)
+		}
+		return err
+	}
+	return nil
+}
+
+// checkPos checks that an error is located within the input, or just past
+// the end of one of its lines
+func checkPos(input string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var perr *parse.Error
+	if !errors.As(err, &perr) {
+		return fmt.Errorf("error without a position: %w", err)
+	}
+	if perr.File != fuzzName {
+		return fmt.Errorf("error in file %q: %w", perr.File, err)
+	}
+	lines := strings.Split(input, "\n")
+	if perr.Line < 1 || perr.Line > len(lines) {
+		return fmt.Errorf("error on line %d of %d: %w", perr.Line, len(lines), err)
+	}
+	if l := len(lines[perr.Line-1]); perr.Col < 1 || perr.Col > l+1 {
+		return fmt.Errorf("error at column %d of a line of %d: %w", perr.Col, l, err)
+	}
+	return nil
+}
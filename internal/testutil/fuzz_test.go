@@ -0,0 +1,41 @@
+package testutil
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func TestFuzz(t *testing.T) {
+	errBad := errors.New("bad input")
+	for _, tc := range []struct {
+		name   string
+		pos    parse.Pos
+		plain  bool
+		passes bool
+	}{
+		{name: "located", pos: parse.Pos{File: fuzzName, Line: 1, Col: 1}, passes: true},
+		{name: "without position", plain: true},
+		{name: "wrong file", pos: parse.Pos{File: "other.txt", Line: 1, Col: 1}},
+		{name: "past last line", pos: parse.Pos{File: fuzzName, Line: 2, Col: 1}},
+		{name: "past end of line", pos: parse.Pos{File: fuzzName, Line: 1, Col: 3}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Fuzz([]string{"a"}, func(r io.Reader) error {
+				if _, err := ioutil.ReadAll(r); err != nil {
+					return err
+				}
+				if tc.plain {
+					return errBad
+				}
+				return &parse.Error{Pos: tc.pos, Err: errBad}
+			})
+			if (err == nil) != tc.passes {
+				t.Fatalf("expected pass %v, got %v", tc.passes, err)
+			}
+		})
+	}
+}
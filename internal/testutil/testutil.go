@@ -0,0 +1,68 @@
+// Package testutil holds helpers shared by the tests of the puzzle packages
+package testutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+// ReadFile reads a file from the testdata directory of the package under
+// test. The name may start with ../ to share the fixtures of a neighbouring
+// package.
+func ReadFile(t testing.TB, name string) *bytes.Reader {
+	t.Helper()
+	b, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(b)
+}
+
+// ReadInput reads the puzzle input of the package under test, skipping the
+// test when there is none, as in a checkout without the inputs
+func ReadInput(t testing.TB) io.Reader {
+	t.Helper()
+	b, err := ioutil.ReadFile("input.txt")
+	if os.IsNotExist(err) {
+		t.Skip("no input")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parse.Named("input.txt", bytes.NewReader(b))
+}
+
+// CheckAnswers solves the puzzle input with each part in turn and compares
+// the answers with answers.txt
+func CheckAnswers(t *testing.T, parts ...aoc.PartFunc) {
+	t.Helper()
+	b, err := ioutil.ReadFile("answers.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := aoc.ParseAnswers(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n, part := range parts {
+		n := n + 1
+		part := part
+		t.Run(fmt.Sprintf("part%d", n), func(t *testing.T) {
+			a, err := part(ReadInput(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := expected.Check(n, a); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
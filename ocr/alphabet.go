@@ -0,0 +1,126 @@
+package ocr
+
+import (
+	"strings"
+)
+
+type (
+	// Alphabet is a set of fixed height block letter glyphs, most of which
+	// are Width columns wide
+	Alphabet struct {
+		Name   string
+		Width  int
+		Height int
+		glyphs map[string]byte
+		widest int
+	}
+)
+
+// NewAlphabet builds an alphabet from glyph pictures drawn with # and .,
+// one row per line
+func NewAlphabet(name string, width, height int, pictures map[byte]string) *Alphabet {
+	glyphs := make(map[string]byte, len(pictures))
+	widest := width
+	for k, v := range pictures {
+		bits := FromText(v, '#')
+		start, end := 0, len(bits[0])
+		for start < end && colEmpty(bits, start) {
+			start++
+		}
+		for end > start && colEmpty(bits, end-1) {
+			end--
+		}
+		glyphs[glyphKey(bits, start, end)] = k
+		if end-start > widest {
+			widest = end - start
+		}
+	}
+	return &Alphabet{
+		Name:   name,
+		Width:  width,
+		Height: height,
+		glyphs: glyphs,
+		widest: widest,
+	}
+}
+
+var (
+	// Small is the 4x6 alphabet of the space image and hull painting puzzles,
+	// where only Y is drawn 5 columns wide
+	Small = NewAlphabet("4x6", 4, 6, map[byte]string{
+		'A': ".##.\n#..#\n#..#\n####\n#..#\n#..#",
+		'B': "###.\n#..#\n###.\n#..#\n#..#\n###.",
+		'C': ".##.\n#..#\n#...\n#...\n#..#\n.##.",
+		'E': "####\n#...\n###.\n#...\n#...\n####",
+		'F': "####\n#...\n###.\n#...\n#...\n#...",
+		'G': ".##.\n#..#\n#...\n#.##\n#..#\n.###",
+		'H': "#..#\n#..#\n####\n#..#\n#..#\n#..#",
+		'I': ".###\n..#.\n..#.\n..#.\n..#.\n.###",
+		'J': "..##\n...#\n...#\n...#\n#..#\n.##.",
+		'K': "#..#\n#.#.\n##..\n#.#.\n#.#.\n#..#",
+		'L': "#...\n#...\n#...\n#...\n#...\n####",
+		'O': ".##.\n#..#\n#..#\n#..#\n#..#\n.##.",
+		'P': "###.\n#..#\n#..#\n###.\n#...\n#...",
+		'R': "###.\n#..#\n#..#\n###.\n#.#.\n#..#",
+		'S': ".###\n#...\n#...\n.##.\n...#\n###.",
+		'U': "#..#\n#..#\n#..#\n#..#\n#..#\n.##.",
+		'Y': "#...#\n#...#\n.#.#.\n..#..\n..#..\n..#..",
+		'Z': "####\n...#\n..#.\n.#..\n#...\n####",
+	})
+
+	// Large is the taller 6x10 alphabet
+	Large = NewAlphabet("6x10", 6, 10, map[byte]string{
+		'A': "..##..\n.#..#.\n#....#\n#....#\n#....#\n######\n#....#\n#....#\n#....#\n#....#",
+		'B': "#####.\n#....#\n#....#\n#....#\n#####.\n#....#\n#....#\n#....#\n#....#\n#####.",
+		'C': ".####.\n#....#\n#.....\n#.....\n#.....\n#.....\n#.....\n#.....\n#....#\n.####.",
+		'E': "######\n#.....\n#.....\n#.....\n#####.\n#.....\n#.....\n#.....\n#.....\n######",
+		'F': "######\n#.....\n#.....\n#.....\n#####.\n#.....\n#.....\n#.....\n#.....\n#.....",
+		'G': ".####.\n#....#\n#.....\n#.....\n#.....\n#..###\n#....#\n#....#\n#...##\n.###.#",
+		'H': "#....#\n#....#\n#....#\n#....#\n######\n#....#\n#....#\n#....#\n#....#\n#....#",
+		'J': "...###\n....#.\n....#.\n....#.\n....#.\n....#.\n....#.\n#...#.\n#...#.\n.###..",
+		'K': "#....#\n#...#.\n#..#..\n#.#...\n##....\n##....\n#.#...\n#..#..\n#...#.\n#....#",
+		'L': "#.....\n#.....\n#.....\n#.....\n#.....\n#.....\n#.....\n#.....\n#.....\n######",
+		'N': "#....#\n##...#\n##...#\n#.#..#\n#.#..#\n#..#.#\n#..#.#\n#...##\n#...##\n#....#",
+		'P': "#####.\n#....#\n#....#\n#....#\n#####.\n#.....\n#.....\n#.....\n#.....\n#.....",
+		'R': "#####.\n#....#\n#....#\n#....#\n#####.\n#..#..\n#...#.\n#...#.\n#....#\n#....#",
+		'X': "#....#\n#....#\n.#..#.\n.#..#.\n..##..\n..##..\n.#..#.\n.#..#.\n#....#\n#....#",
+		'Z': "######\n.....#\n.....#\n....#.\n...#..\n..#...\n.#....\n#.....\n#.....\n######",
+	})
+
+	alphabets = []*Alphabet{Small, Large}
+)
+
+func colEmpty(bits [][]bool, col int) bool {
+	for _, i := range bits {
+		if col < len(i) && i[col] {
+			return false
+		}
+	}
+	return true
+}
+
+func rowEmpty(row []bool) bool {
+	for _, i := range row {
+		if i {
+			return false
+		}
+	}
+	return true
+}
+
+func glyphKey(bits [][]bool, start, end int) string {
+	b := strings.Builder{}
+	for n, i := range bits {
+		if n > 0 {
+			b.WriteByte('\n')
+		}
+		for col := start; col < end; col++ {
+			if col < len(i) && i[col] {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,143 @@
+package ocr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownGlyph   = errors.New("unknown glyph")
+	ErrAmbiguousGlyph = errors.New("ambiguous glyph")
+	ErrNoAlphabet     = errors.New("no alphabet matches text height")
+)
+
+type (
+	// GlyphError describes the glyph that could not be read, and where it is
+	GlyphError struct {
+		Err      error
+		Alphabet string
+		Col      int
+		Picture  string
+	}
+)
+
+func (e *GlyphError) Error() string {
+	return fmt.Sprintf("%s in %s alphabet at column %d:\n%s", e.Err, e.Alphabet, e.Col, e.Picture)
+}
+
+func (e *GlyphError) Unwrap() error {
+	return e.Err
+}
+
+// FromText converts lines of text to a binary grid, where cells equal to on
+// are set
+func FromText(text string, on byte) [][]bool {
+	lines := strings.Split(strings.Trim(text, "\n"), "\n")
+	bits := make([][]bool, 0, len(lines))
+	for _, i := range lines {
+		row := make([]bool, 0, len(i))
+		for _, j := range []byte(i) {
+			row = append(row, j == on)
+		}
+		bits = append(bits, row)
+	}
+	return bits
+}
+
+// FromInts converts a grid of ints, such as a rendered image layer, to a
+// binary grid, where cells equal to on are set
+func FromInts(grid [][]int, on int) [][]bool {
+	bits := make([][]bool, 0, len(grid))
+	for _, i := range grid {
+		row := make([]bool, 0, len(i))
+		for _, j := range i {
+			row = append(row, j == on)
+		}
+		bits = append(bits, row)
+	}
+	return bits
+}
+
+func trimRows(bits [][]bool) [][]bool {
+	top := 0
+	for top < len(bits) && rowEmpty(bits[top]) {
+		top++
+	}
+	bottom := len(bits)
+	for bottom > top && rowEmpty(bits[bottom-1]) {
+		bottom--
+	}
+	return bits[top:bottom]
+}
+
+// Parse reads the text in a binary grid, choosing the alphabet by the height
+// of the text
+func Parse(bits [][]bool) (string, error) {
+	bits = trimRows(bits)
+	for _, i := range alphabets {
+		if i.Height == len(bits) {
+			return i.Parse(bits)
+		}
+	}
+	return "", fmt.Errorf("%w: %d rows", ErrNoAlphabet, len(bits))
+}
+
+// ParseInts reads the text in a grid of ints, where cells equal to on are
+// set
+func ParseInts(grid [][]int, on int) (string, error) {
+	return Parse(FromInts(grid, on))
+}
+
+// ParseText reads the text drawn in lines of text, where bytes equal to on
+// are set
+func ParseText(text string, on byte) (string, error) {
+	return Parse(FromText(text, on))
+}
+
+// Parse reads the text in a binary grid with this alphabet. Glyphs are split
+// at fully empty columns, so a run of set columns wider than the widest glyph
+// is two touching glyphs that cannot be told apart.
+func (a *Alphabet) Parse(bits [][]bool) (string, error) {
+	bits = trimRows(bits)
+	if len(bits) != a.Height {
+		return "", fmt.Errorf("%w: %d rows for %s alphabet", ErrNoAlphabet, len(bits), a.Name)
+	}
+	width := 0
+	for _, i := range bits {
+		if len(i) > width {
+			width = len(i)
+		}
+	}
+	b := strings.Builder{}
+	for col := 0; col < width; {
+		if colEmpty(bits, col) {
+			col++
+			continue
+		}
+		start := col
+		for col < width && !colEmpty(bits, col) {
+			col++
+		}
+		key := glyphKey(bits, start, col)
+		if col-start > a.widest {
+			return "", &GlyphError{
+				Err:      ErrAmbiguousGlyph,
+				Alphabet: a.Name,
+				Col:      start,
+				Picture:  key,
+			}
+		}
+		c, ok := a.glyphs[key]
+		if !ok {
+			return "", &GlyphError{
+				Err:      ErrUnknownGlyph,
+				Alphabet: a.Name,
+				Col:      start,
+				Picture:  key,
+			}
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,112 @@
+package ocr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// beside draws glyph pictures side by side, separated by gap empty columns
+func beside(gap int, pictures ...string) string {
+	rows := [][]string{}
+	for _, i := range pictures {
+		for n, j := range strings.Split(i, "\n") {
+			if n == len(rows) {
+				rows = append(rows, nil)
+			}
+			rows[n] = append(rows[n], j)
+		}
+	}
+	lines := make([]string, 0, len(rows))
+	for _, i := range rows {
+		lines = append(lines, strings.Join(i, strings.Repeat(".", gap)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+const (
+	smallA = ".##.\n#..#\n#..#\n####\n#..#\n#..#"
+	smallE = "####\n#...\n###.\n#...\n#...\n####"
+	smallI = ".###\n..#.\n..#.\n..#.\n..#.\n.###"
+	smallY = "#...#\n#...#\n.#.#.\n..#..\n..#..\n..#.."
+	largeN = "#....#\n##...#\n##...#\n#.#..#\n#.#..#\n#..#.#\n#..#.#\n#...##\n#...##\n#....#"
+	largeX = "#....#\n#....#\n.#..#.\n.#..#.\n..##..\n..##..\n.#..#.\n.#..#.\n#....#\n#....#"
+)
+
+func TestParseText(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "small", text: beside(1, smallY, smallE, smallA), expected: "YEA"},
+		{name: "small narrow glyph", text: beside(1, smallI, smallY), expected: "IY"},
+		{name: "small wide gap", text: beside(3, smallA, smallY), expected: "AY"},
+		{name: "large", text: beside(2, largeX, largeN), expected: "XN"},
+		{name: "blank rows", text: "\n......\n" + beside(1, smallA, smallE) + "\n.........\n", expected: "AE"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := ParseText(tc.text, '#')
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, s)
+			}
+		})
+	}
+}
+
+func TestParseInts(t *testing.T) {
+	bits := FromText(beside(1, smallE, smallY), '#')
+	ints := make([][]int, 0, len(bits))
+	for _, i := range bits {
+		row := make([]int, 0, len(i))
+		for _, j := range i {
+			if j {
+				row = append(row, 1)
+			} else {
+				row = append(row, 0)
+			}
+		}
+		ints = append(ints, row)
+	}
+	s, err := ParseInts(ints, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "EY" {
+		t.Fatalf("expected EY, got %v", s)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		text string
+		err  error
+		col  int
+	}{
+		{name: "unknown", text: beside(1, smallA, "##..\n##..\n....\n....\n....\n##.."), err: ErrUnknownGlyph, col: 5},
+		{name: "touching", text: beside(0, smallE, smallA), err: ErrAmbiguousGlyph, col: 0},
+		{name: "wider than width", text: beside(1, smallA, "######\n#....#\n#....#\n#....#\n#....#\n######"), err: ErrAmbiguousGlyph, col: 5},
+		{name: "height", text: "###\n#.#\n###", err: ErrNoAlphabet, col: -1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseText(tc.text, '#')
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+			if tc.col < 0 {
+				return
+			}
+			var gerr *GlyphError
+			if !errors.As(err, &gerr) {
+				t.Fatalf("expected glyph error, got %v", err)
+			}
+			if gerr.Col != tc.col || gerr.Alphabet != Small.Name {
+				t.Fatalf("expected column %d of %s, got %v", tc.col, Small.Name, gerr)
+			}
+		})
+	}
+}
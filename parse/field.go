@@ -0,0 +1,196 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInt   = errors.New("invalid integer")
+	ErrDigit = errors.New("invalid digit")
+	ErrEmpty = errors.New("empty field")
+	ErrSep   = errors.New("missing separator")
+)
+
+type (
+	// Pos is a position in an input, with lines and columns counted from 1
+	// and columns counted in bytes
+	Pos struct {
+		File string
+		Line int
+		Col  int
+	}
+
+	// Error locates an error in an input
+	Error struct {
+		Pos
+		Err error
+	}
+
+	// Field is a piece of a line of input with the position it starts at
+	Field struct {
+		Text string
+		Pos  Pos
+	}
+)
+
+func (p Pos) String() string {
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Col)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Col)
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %v", e.Pos, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errorf returns an error located at the start of the field
+func (f Field) Errorf(format string, args ...interface{}) error {
+	return &Error{
+		Pos: f.Pos,
+		Err: fmt.Errorf(format, args...),
+	}
+}
+
+// End is the position just past the end of the field
+func (f Field) End() Pos {
+	p := f.Pos
+	p.Col += len(f.Text)
+	return p
+}
+
+// EndErrorf returns an error located just past the end of the field, for
+// something missing from it
+func (f Field) EndErrorf(format string, args ...interface{}) error {
+	return &Error{
+		Pos: f.End(),
+		Err: fmt.Errorf(format, args...),
+	}
+}
+
+// Sub returns the part of the field from byte start up to end
+func (f Field) Sub(start, end int) Field {
+	p := f.Pos
+	p.Col += start
+	return Field{
+		Text: f.Text[start:end],
+		Pos:  p,
+	}
+}
+
+func (f Field) TrimSpace() Field {
+	start := len(f.Text) - len(strings.TrimLeftFunc(f.Text, unicode.IsSpace))
+	end := len(strings.TrimRightFunc(f.Text, unicode.IsSpace))
+	if end < start {
+		end = start
+	}
+	return f.Sub(start, end)
+}
+
+// Trim removes the leading prefix and trailing suffix when present
+func (f Field) Trim(prefix, suffix string) Field {
+	start := 0
+	end := len(f.Text)
+	if strings.HasPrefix(f.Text, prefix) {
+		start = len(prefix)
+	}
+	if strings.HasSuffix(f.Text[start:], suffix) {
+		end -= len(suffix)
+	}
+	return f.Sub(start, end)
+}
+
+// Split returns the fields separated by sep, which are not trimmed
+func (f Field) Split(sep string) []Field {
+	fields := []Field{}
+	start := 0
+	for {
+		k := strings.Index(f.Text[start:], sep)
+		if k < 0 {
+			break
+		}
+		fields = append(fields, f.Sub(start, start+k))
+		start += k + len(sep)
+	}
+	return append(fields, f.Sub(start, len(f.Text)))
+}
+
+// Cut splits the field around the first sep, with both sides trimmed and
+// neither empty
+func (f Field) Cut(sep string) (Field, Field, error) {
+	k := strings.Index(f.Text, sep)
+	if k < 0 {
+		return Field{}, Field{}, f.EndErrorf("%w: expected %q", ErrSep, sep)
+	}
+	left := f.Sub(0, k).TrimSpace()
+	if len(left.Text) == 0 {
+		return Field{}, Field{}, f.Sub(k, k).Errorf("%w before %q", ErrEmpty, sep)
+	}
+	right := f.Sub(k+len(sep), len(f.Text))
+	if r := right.TrimSpace(); len(r.Text) > 0 {
+		right = r
+	} else {
+		return Field{}, Field{}, right.EndErrorf("%w after %q", ErrEmpty, sep)
+	}
+	return left, right, nil
+}
+
+// Words returns the fields separated by whitespace
+func (f Field) Words() []Field {
+	words := []Field{}
+	start := -1
+	for n, i := range f.Text {
+		if unicode.IsSpace(i) {
+			if start >= 0 {
+				words = append(words, f.Sub(start, n))
+				start = -1
+			}
+		} else if start < 0 {
+			start = n
+		}
+	}
+	if start >= 0 {
+		words = append(words, f.Sub(start, len(f.Text)))
+	}
+	return words
+}
+
+// Int parses the field, ignoring surrounding whitespace, as a decimal
+// integer
+func (f Field) Int() (int, error) {
+	num, err := f.Int64()
+	return int(num), err
+}
+
+func (f Field) Int64() (int64, error) {
+	t := f.TrimSpace()
+	if len(t.Text) == 0 {
+		return 0, t.Errorf("%w: expected an integer", ErrEmpty)
+	}
+	num, err := strconv.ParseInt(t.Text, 10, 64)
+	if err != nil {
+		return 0, t.Errorf("%w: %q", ErrInt, t.Text)
+	}
+	return num, nil
+}
+
+// Digits parses every byte of the field as a decimal digit
+func (f Field) Digits() ([]int, error) {
+	digits := make([]int, 0, len(f.Text))
+	for n := 0; n < len(f.Text); n++ {
+		c := f.Text[n]
+		if c < '0' || c > '9' {
+			return nil, f.Sub(n, n+1).Errorf("%w: %q", ErrDigit, c)
+		}
+		digits = append(digits, int(c-'0'))
+	}
+	return digits, nil
+}
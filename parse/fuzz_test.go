@@ -0,0 +1,63 @@
+package parse_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/xorkevin/advent2019/internal/testutil"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+// TestFuzzInts covers the masses of day 1
+func TestFuzzInts(t *testing.T) {
+	if err := testutil.Fuzz([]string{"0", "7", "42", "-", "+", " ", "\n", "\r\n", "x"}, func(r io.Reader) error {
+		_, err := parse.Ints(r)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestFuzzCSVInts covers the intcode programs of days 2, 5, 7, 9, 11, 13, 15,
+// 17, 19 and 21
+func TestFuzzCSVInts(t *testing.T) {
+	if err := testutil.Fuzz([]string{"0", "7", "42", "-", ",", ", ", " ", "\n", "99999999999999999999"}, func(r io.Reader) error {
+		_, err := parse.CSVInts(r)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFuzzDigits(t *testing.T) {
+	if err := testutil.Fuzz([]string{"0", "12", "9", " ", "\n", "a"}, func(r io.Reader) error {
+		_, err := parse.Digits(r)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFuzzGrid(t *testing.T) {
+	if err := testutil.Fuzz([]string{"#", ".", "..", "\n", "?"}, func(r io.Reader) error {
+		_, err := parse.Grid(r, '.', func(c byte) bool {
+			return c == '#' || c == '.'
+		})
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFuzzPairs(t *testing.T) {
+	for _, sep := range []string{")", "=>"} {
+		t.Run(sep, func(t *testing.T) {
+			if err := testutil.Fuzz([]string{"A", "B1", ")", "=>", "=", ">", " ", "\n"}, func(r io.Reader) error {
+				_, err := parse.Pairs(r, sep)
+				return err
+			}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package parse
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/xorkevin/advent2019/grid"
+)
+
+var (
+	ErrNoData = errors.New("no input")
+	ErrExtra  = errors.New("unexpected input")
+	ErrChar   = errors.New("invalid character")
+)
+
+type (
+	named struct {
+		io.Reader
+		name string
+	}
+
+	// Pair is a line made of two fields around a separator
+	Pair struct {
+		Left  Field
+		Right Field
+	}
+)
+
+// Named gives an input a name, such as its path, for the positions of
+// errors. An *os.File is named already.
+func Named(name string, r io.Reader) io.Reader {
+	return named{
+		Reader: r,
+		name:   name,
+	}
+}
+
+func (n named) Name() string {
+	return n.name
+}
+
+// NameOf is the name of an input, if it has one
+func NameOf(r io.Reader) string {
+	if n, ok := r.(interface{ Name() string }); ok {
+		return n.Name()
+	}
+	return ""
+}
+
+// Lines reads every line of an input, without any trailing carriage return
+func Lines(r io.Reader) ([]Field, error) {
+	name := NameOf(r)
+	lines := []Field{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		lines = append(lines, Field{
+			Text: strings.TrimSuffix(scanner.Text(), "\r"),
+			Pos: Pos{
+				File: name,
+				Line: n,
+				Col:  1,
+			},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// NonBlank reads the lines of an input which are not blank
+func NonBlank(r io.Reader) ([]Field, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+	k := lines[:0]
+	for _, i := range lines {
+		if len(strings.TrimSpace(i.Text)) > 0 {
+			k = append(k, i)
+		}
+	}
+	return k, nil
+}
+
+// start is the position of an empty input
+func start(r io.Reader) Field {
+	return Field{
+		Pos: Pos{
+			File: NameOf(r),
+			Line: 1,
+			Col:  1,
+		},
+	}
+}
+
+// Line reads an input of a single non blank line, trimmed of whitespace
+func Line(r io.Reader) (Field, error) {
+	s := start(r)
+	lines, err := NonBlank(r)
+	if err != nil {
+		return Field{}, err
+	}
+	if len(lines) == 0 {
+		return Field{}, s.Errorf("%w", ErrNoData)
+	}
+	if len(lines) > 1 {
+		return Field{}, lines[1].TrimSpace().Errorf("%w: expected a single line", ErrExtra)
+	}
+	return lines[0].TrimSpace(), nil
+}
+
+// Ints reads one integer per line, skipping blank lines
+func Ints(r io.Reader) ([]int, error) {
+	lines, err := NonBlank(r)
+	if err != nil {
+		return nil, err
+	}
+	nums := make([]int, 0, len(lines))
+	for _, i := range lines {
+		num, err := i.Int()
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, num)
+	}
+	return nums, nil
+}
+
+// CSVInts reads integers separated by commas, such as an intcode program,
+// which may span several lines. Every field must hold an integer, so an
+// empty field, such as one after a trailing comma, is an error.
+func CSVInts(r io.Reader) ([]int, error) {
+	lines, err := NonBlank(r)
+	if err != nil {
+		return nil, err
+	}
+	nums := []int{}
+	for _, i := range lines {
+		for _, j := range i.Split(",") {
+			num, err := j.Int()
+			if err != nil {
+				return nil, err
+			}
+			nums = append(nums, num)
+		}
+	}
+	return nums, nil
+}
+
+// Digits reads a single line of decimal digits
+func Digits(r io.Reader) ([]int, error) {
+	line, err := Line(r)
+	if err != nil {
+		return nil, err
+	}
+	return line.Digits()
+}
+
+// Grid reads a grid with one row per line, keeping blank lines within the
+// grid but dropping trailing ones. Every cell must be valid, when valid is
+// not nil.
+func Grid(r io.Reader, fill byte, valid func(c byte) bool) (*grid.Dense, error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+	for len(lines) > 0 && len(lines[len(lines)-1].Text) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	rows := make([][]byte, 0, len(lines))
+	for _, i := range lines {
+		if valid != nil {
+			for n := 0; n < len(i.Text); n++ {
+				if c := i.Text[n]; !valid(c) {
+					return nil, i.Sub(n, n+1).Errorf("%w: %q", ErrChar, c)
+				}
+			}
+		}
+		rows = append(rows, []byte(i.Text))
+	}
+	return grid.FromLines(rows, fill), nil
+}
+
+// Pairs reads one pair of fields around a single sep per line, such as A)B
+// or A => B, skipping blank lines
+func Pairs(r io.Reader, sep string) ([]Pair, error) {
+	lines, err := NonBlank(r)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([]Pair, 0, len(lines))
+	for _, i := range lines {
+		left, right, err := i.Cut(sep)
+		if err != nil {
+			return nil, err
+		}
+		if k := strings.Index(right.Text, sep); k >= 0 {
+			return nil, right.Sub(k, k+len(sep)).Errorf("%w: second %q", ErrExtra, sep)
+		}
+		pairs = append(pairs, Pair{
+			Left:  left,
+			Right: right,
+		})
+	}
+	return pairs, nil
+}
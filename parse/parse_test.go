@@ -0,0 +1,169 @@
+package parse
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestErrorPos(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		input    string
+		parser   func(r io.Reader) error
+		expected string
+		err      error
+	}{
+		{
+			name:  "ints",
+			input: "12\n\n  3x\n",
+			parser: func(r io.Reader) error {
+				_, err := Ints(r)
+				return err
+			},
+			expected: "in.txt:3:3",
+			err:      ErrInt,
+		},
+		{
+			name:  "csv empty field",
+			input: "1,2,,4\n",
+			parser: func(r io.Reader) error {
+				_, err := CSVInts(r)
+				return err
+			},
+			expected: "in.txt:1:5",
+			err:      ErrEmpty,
+		},
+		{
+			name:  "csv trailing comma",
+			input: "1,2,\n",
+			parser: func(r io.Reader) error {
+				_, err := CSVInts(r)
+				return err
+			},
+			expected: "in.txt:1:5",
+			err:      ErrEmpty,
+		},
+		{
+			name:  "digits",
+			input: " 0123a5\n",
+			parser: func(r io.Reader) error {
+				_, err := Digits(r)
+				return err
+			},
+			expected: "in.txt:1:6",
+			err:      ErrDigit,
+		},
+		{
+			name:  "digits extra line",
+			input: "0123\n\n 45\n",
+			parser: func(r io.Reader) error {
+				_, err := Digits(r)
+				return err
+			},
+			expected: "in.txt:3:2",
+			err:      ErrExtra,
+		},
+		{
+			name:  "empty",
+			input: "\n\n",
+			parser: func(r io.Reader) error {
+				_, err := Digits(r)
+				return err
+			},
+			expected: "in.txt:1:1",
+			err:      ErrNoData,
+		},
+		{
+			name:  "grid",
+			input: "#.#\n.#?\n",
+			parser: func(r io.Reader) error {
+				_, err := Grid(r, '.', func(c byte) bool {
+					return c == '#' || c == '.'
+				})
+				return err
+			},
+			expected: "in.txt:2:3",
+			err:      ErrChar,
+		},
+		{
+			name:  "pair missing separator",
+			input: "A)B\nCD\n",
+			parser: func(r io.Reader) error {
+				_, err := Pairs(r, ")")
+				return err
+			},
+			expected: "in.txt:2:3",
+			err:      ErrSep,
+		},
+		{
+			name:  "pair second separator",
+			input: "A)B)C\n",
+			parser: func(r io.Reader) error {
+				_, err := Pairs(r, ")")
+				return err
+			},
+			expected: "in.txt:1:4",
+			err:      ErrExtra,
+		},
+		{
+			name:  "pair empty right",
+			input: "1 A =>  \n",
+			parser: func(r io.Reader) error {
+				_, err := Pairs(r, "=>")
+				return err
+			},
+			expected: "in.txt:1:9",
+			err:      ErrEmpty,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.parser(Named("in.txt", strings.NewReader(tc.input)))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+			var perr *Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("expected a located error, got %v", err)
+			}
+			if pos := perr.Pos.String(); pos != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, pos)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	nums, err := CSVInts(strings.NewReader("1, -2,3\n4\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []int{1, -2, 3, 4}; !reflect.DeepEqual(nums, expected) {
+		t.Fatalf("expected %v, got %v", expected, nums)
+	}
+
+	pairs, err := Pairs(strings.NewReader("7 A, 1 B => 1 C\n"), "=>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := pairs[0]
+	if p.Left.Text != "7 A, 1 B" || p.Right.Text != "1 C" || p.Right.Pos.Col != 13 {
+		t.Fatalf("unexpected pair %+v", p)
+	}
+
+	words := Field{Text: " deal  with 7", Pos: Pos{Line: 1, Col: 1}}.Words()
+	cols := []int{}
+	for _, i := range words {
+		cols = append(cols, i.Pos.Col)
+	}
+	if expected := []int{2, 8, 13}; !reflect.DeepEqual(cols, expected) {
+		t.Fatalf("expected %v, got %v", expected, cols)
+	}
+
+	f := Field{Text: "<x=1>", Pos: Pos{Line: 1, Col: 1}}.Trim("<", ">")
+	if f.Text != "x=1" || f.Pos.Col != 2 {
+		t.Fatalf("unexpected field %+v", f)
+	}
+}
@@ -0,0 +1,255 @@
+package search
+
+import (
+	"container/heap"
+)
+
+type (
+	// Node is a search state. Nodes are used as map keys, so they must be
+	// comparable.
+	Node interface{}
+
+	// Edge is a move to a neighboring node. Data is carried along unchanged
+	// so that callers can recover how each step of a path was taken.
+	Edge struct {
+		To   Node
+		Cost int
+		Data interface{}
+	}
+
+	// Graph expands a node into its neighbors
+	Graph interface {
+		Neighbors(n Node) []Edge
+	}
+
+	// GraphFunc adapts a function to a Graph
+	GraphFunc func(n Node) []Edge
+
+	// Heuristic estimates the remaining cost from a node to the nearest goal.
+	// Since A* closes each node the first time it is popped, the heuristic
+	// must be consistent for A* to return a shortest path: it must be 0 at
+	// every goal and never drop by more than the cost of an edge. Being
+	// admissible, never overestimating, is not enough.
+	Heuristic func(n Node) int
+
+	// Goal reports whether a node ends the search. A nil Goal explores every
+	// reachable node.
+	Goal func(n Node) bool
+
+	// Step is one edge of a path
+	Step struct {
+		From Node
+		Edge
+	}
+
+	// Result holds the distances and parents of every node reached by a
+	// search, and the goal if one was found. A search that stops early at a
+	// goal leaves some reached nodes unclosed, and their distances and paths
+	// are only tentative.
+	Result struct {
+		Goal    Node
+		Found   bool
+		Visited int
+		dist    map[Node]int
+		parents map[Node]Step
+	}
+)
+
+func (f GraphFunc) Neighbors(n Node) []Edge {
+	return f(n)
+}
+
+func newResult() *Result {
+	return &Result{
+		dist:    map[Node]int{},
+		parents: map[Node]Step{},
+	}
+}
+
+// Dist returns the cost to reach a node from the nearest start. After
+// Dijkstra or AStar stop early at a goal, the cost of a node that was reached
+// but not closed is only the cheapest found so far, and may be more than its
+// true cost.
+func (r *Result) Dist(n Node) (int, bool) {
+	d, ok := r.dist[n]
+	return d, ok
+}
+
+// GoalDist returns the cost to reach the goal, or -1 if none was found
+func (r *Result) GoalDist() int {
+	if !r.Found {
+		return -1
+	}
+	return r.dist[r.Goal]
+}
+
+// Reached returns every node reached by the search
+func (r *Result) Reached() []Node {
+	nodes := make([]Node, 0, len(r.dist))
+	for k := range r.dist {
+		nodes = append(nodes, k)
+	}
+	return nodes
+}
+
+// Steps returns the edges taken from a start to the node
+func (r *Result) Steps(n Node) []Step {
+	steps := []Step{}
+	for {
+		p, ok := r.parents[n]
+		if !ok {
+			break
+		}
+		steps = append(steps, p)
+		n = p.From
+	}
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return steps
+}
+
+// Path returns the nodes from a start to the node, including both ends
+func (r *Result) Path(n Node) []Node {
+	if _, ok := r.dist[n]; !ok {
+		return nil
+	}
+	steps := r.Steps(n)
+	path := make([]Node, 0, len(steps)+1)
+	if len(steps) == 0 {
+		return append(path, n)
+	}
+	path = append(path, steps[0].From)
+	for _, i := range steps {
+		path = append(path, i.To)
+	}
+	return path
+}
+
+// BFS searches outwards from the starts, treating every edge as a single
+// step regardless of its cost
+func BFS(g Graph, starts []Node, goal Goal) *Result {
+	r := newResult()
+	queue := make([]Node, 0, len(starts))
+	for _, i := range starts {
+		if _, ok := r.dist[i]; ok {
+			continue
+		}
+		r.dist[i] = 0
+		queue = append(queue, i)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		r.Visited++
+		if goal != nil && goal(cur) {
+			r.Goal = cur
+			r.Found = true
+			return r
+		}
+		d := r.dist[cur]
+		for _, e := range g.Neighbors(cur) {
+			if _, ok := r.dist[e.To]; ok {
+				continue
+			}
+			r.dist[e.To] = d + 1
+			r.parents[e.To] = Step{
+				From: cur,
+				Edge: Edge{
+					To:   e.To,
+					Cost: 1,
+					Data: e.Data,
+				},
+			}
+			queue = append(queue, e.To)
+		}
+	}
+	return r
+}
+
+type (
+	item struct {
+		node Node
+		g, f int
+	}
+
+	queue []item
+)
+
+func (pq queue) Len() int { return len(pq) }
+func (pq queue) Less(i, j int) bool {
+	return pq[i].f < pq[j].f
+}
+func (pq queue) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+}
+func (pq *queue) Push(x interface{}) {
+	*pq = append(*pq, x.(item))
+}
+func (pq *queue) Pop() interface{} {
+	old := *pq
+	n := len(old)
+	it := old[n-1]
+	*pq = old[0 : n-1]
+	return it
+}
+
+// Dijkstra finds the cheapest paths from the starts
+func Dijkstra(g Graph, starts []Node, goal Goal) *Result {
+	return AStar(g, starts, goal, nil)
+}
+
+// AStar finds the cheapest path from the starts to a goal, ordering the
+// search by cost so far plus the heuristic. Instead of decreasing keys in
+// place, a cheaper path pushes a new entry and stale entries are skipped
+// when popped. A nil heuristic is Dijkstra.
+func AStar(g Graph, starts []Node, goal Goal, h Heuristic) *Result {
+	r := newResult()
+	closed := map[Node]struct{}{}
+	pq := &queue{}
+	est := func(n Node) int {
+		if h == nil {
+			return 0
+		}
+		return h(n)
+	}
+	for _, i := range starts {
+		if _, ok := r.dist[i]; ok {
+			continue
+		}
+		r.dist[i] = 0
+		heap.Push(pq, item{node: i, g: 0, f: est(i)})
+	}
+	for pq.Len() > 0 {
+		cur := heap.Pop(pq).(item)
+		if _, ok := closed[cur.node]; ok {
+			continue
+		}
+		if cur.g > r.dist[cur.node] {
+			continue
+		}
+		closed[cur.node] = struct{}{}
+		r.Visited++
+		if goal != nil && goal(cur.node) {
+			r.Goal = cur.node
+			r.Found = true
+			return r
+		}
+		for _, e := range g.Neighbors(cur.node) {
+			if _, ok := closed[e.To]; ok {
+				continue
+			}
+			d := cur.g + e.Cost
+			if v, ok := r.dist[e.To]; ok && v <= d {
+				continue
+			}
+			r.dist[e.To] = d
+			r.parents[e.To] = Step{
+				From: cur.node,
+				Edge: e,
+			}
+			heap.Push(pq, item{node: e.To, g: d, f: d + est(e.To)})
+		}
+	}
+	return r
+}
@@ -0,0 +1,214 @@
+package search
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type (
+	pos struct {
+		x, y int
+	}
+)
+
+// graph is a small undirected weighted graph, where E is unreachable:
+//
+//	A -1- B -5- D -2- F
+//	 \    |    /
+//	  4   1   1
+//	   \  |  /
+//	      C       E
+func graph() Graph {
+	edges := map[string][]Edge{}
+	link := func(a, b string, cost int) {
+		edges[a] = append(edges[a], Edge{To: b, Cost: cost, Data: a + b})
+		edges[b] = append(edges[b], Edge{To: a, Cost: cost, Data: b + a})
+	}
+	link("A", "B", 1)
+	link("A", "C", 4)
+	link("B", "C", 1)
+	link("B", "D", 5)
+	link("C", "D", 1)
+	link("D", "F", 2)
+	edges["E"] = nil
+	return GraphFunc(func(n Node) []Edge {
+		return edges[n.(string)]
+	})
+}
+
+func is(target string) Goal {
+	return func(n Node) bool {
+		return n.(string) == target
+	}
+}
+
+func nodes(names ...string) []Node {
+	n := make([]Node, 0, len(names))
+	for _, i := range names {
+		n = append(n, i)
+	}
+	return n
+}
+
+func TestSearch(t *testing.T) {
+	type search func(g Graph, starts []Node, goal Goal) *Result
+	bfs := BFS
+	dijkstra := Dijkstra
+	for _, tc := range []struct {
+		name   string
+		search search
+		starts []Node
+		goal   string
+		dist   int
+		path   []Node
+		data   []interface{}
+	}{
+		{name: "bfs", search: bfs, starts: nodes("A"), goal: "F", dist: 3, path: nodes("A", "B", "D", "F"), data: []interface{}{"AB", "BD", "DF"}},
+		{name: "bfs multi source", search: bfs, starts: nodes("A", "F"), goal: "D", dist: 1, path: nodes("F", "D"), data: []interface{}{"FD"}},
+		{name: "bfs start is goal", search: bfs, starts: nodes("C"), goal: "C", dist: 0, path: nodes("C"), data: []interface{}{}},
+		{name: "dijkstra", search: dijkstra, starts: nodes("A"), goal: "F", dist: 5, path: nodes("A", "B", "C", "D", "F"), data: []interface{}{"AB", "BC", "CD", "DF"}},
+		{name: "dijkstra multi source", search: dijkstra, starts: nodes("A", "F", "A"), goal: "D", dist: 2, path: nodes("F", "D"), data: []interface{}{"FD"}},
+		{name: "dijkstra start is goal", search: dijkstra, starts: nodes("C"), goal: "C", dist: 0, path: nodes("C"), data: []interface{}{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.search(graph(), tc.starts, is(tc.goal))
+			if !r.Found || r.Goal != tc.goal {
+				t.Fatalf("expected to find %s, got %v", tc.goal, r.Goal)
+			}
+			if d := r.GoalDist(); d != tc.dist {
+				t.Fatalf("expected distance %d, got %d", tc.dist, d)
+			}
+			if d, ok := r.Dist(tc.goal); !ok || d != tc.dist {
+				t.Fatalf("expected distance %d, got %d", tc.dist, d)
+			}
+			if path := r.Path(tc.goal); !reflect.DeepEqual(path, tc.path) {
+				t.Fatalf("expected path %v, got %v", tc.path, path)
+			}
+			steps := r.Steps(tc.goal)
+			data := make([]interface{}, 0, len(steps))
+			for n, i := range steps {
+				if i.From != tc.path[n] || i.To != tc.path[n+1] {
+					t.Fatalf("expected step from %v to %v, got %v to %v", tc.path[n], tc.path[n+1], i.From, i.To)
+				}
+				data = append(data, i.Data)
+			}
+			if !reflect.DeepEqual(data, tc.data) {
+				t.Fatalf("expected steps %v, got %v", tc.data, data)
+			}
+		})
+	}
+}
+
+func TestEarlyExit(t *testing.T) {
+	r := Dijkstra(graph(), nodes("A"), is("B"))
+	if !r.Found || r.GoalDist() != 1 {
+		t.Fatalf("expected to find B at 1, got %v at %d", r.Goal, r.GoalDist())
+	}
+	// only A and B are closed, before B is expanded
+	if r.Visited != 2 {
+		t.Fatalf("expected 2 visited, got %d", r.Visited)
+	}
+	// C was reached from A but not closed, so its distance is tentative
+	if d, ok := r.Dist("C"); !ok || d != 4 {
+		t.Fatalf("expected tentative distance 4 to C, got %d, %t", d, ok)
+	}
+	if _, ok := r.Dist("D"); ok {
+		t.Fatal("D reached before B was expanded")
+	}
+
+	r = BFS(graph(), nodes("A"), is("B"))
+	if r.Visited != 2 {
+		t.Fatalf("expected 2 visited, got %d", r.Visited)
+	}
+}
+
+func TestUnreachable(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		search func(g Graph, starts []Node, goal Goal) *Result
+	}{
+		{name: "bfs", search: BFS},
+		{name: "dijkstra", search: Dijkstra},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.search(graph(), nodes("A"), is("E"))
+			if r.Found {
+				t.Fatalf("found unreachable goal %v", r.Goal)
+			}
+			if d := r.GoalDist(); d != -1 {
+				t.Fatalf("expected -1, got %d", d)
+			}
+			if _, ok := r.Dist("E"); ok {
+				t.Fatal("reached E")
+			}
+			if path := r.Path("E"); path != nil {
+				t.Fatalf("expected no path, got %v", path)
+			}
+			if r.Visited != 5 {
+				t.Fatalf("expected 5 visited, got %d", r.Visited)
+			}
+			reached := []string{}
+			for _, i := range r.Reached() {
+				reached = append(reached, i.(string))
+			}
+			sort.Strings(reached)
+			if expected := []string{"A", "B", "C", "D", "F"}; !reflect.DeepEqual(reached, expected) {
+				t.Fatalf("expected %v, got %v", expected, reached)
+			}
+		})
+	}
+}
+
+func TestAStar(t *testing.T) {
+	walls := []string{
+		"..........",
+		".########.",
+		"........#.",
+		"#######.#.",
+		"..........",
+	}
+	open := func(p pos) bool {
+		return p.y >= 0 && p.y < len(walls) && p.x >= 0 && p.x < len(walls[p.y]) && walls[p.y][p.x] == '.'
+	}
+	g := GraphFunc(func(n Node) []Edge {
+		p := n.(pos)
+		edges := []Edge{}
+		for _, i := range []pos{{p.x + 1, p.y}, {p.x - 1, p.y}, {p.x, p.y + 1}, {p.x, p.y - 1}} {
+			if open(i) {
+				edges = append(edges, Edge{To: i, Cost: 1})
+			}
+		}
+		return edges
+	})
+	target := pos{0, 4}
+	goal := func(n Node) bool {
+		return n.(pos) == target
+	}
+	manhattan := func(n Node) int {
+		p := n.(pos)
+		dx, dy := p.x-target.x, p.y-target.y
+		if dx < 0 {
+			dx = -dx
+		}
+		if dy < 0 {
+			dy = -dy
+		}
+		return dx + dy
+	}
+	starts := []Node{pos{0, 2}}
+	d := Dijkstra(g, starts, goal)
+	a := AStar(g, starts, goal, manhattan)
+	if !d.Found || !a.Found {
+		t.Fatal("goal not found")
+	}
+	if d.GoalDist() != 16 || a.GoalDist() != 16 {
+		t.Fatalf("expected 16, got %d and %d", d.GoalDist(), a.GoalDist())
+	}
+	if p := a.Path(target); len(p) != 17 {
+		t.Fatalf("expected a path of 17 nodes, got %v", p)
+	}
+	if a.Visited > d.Visited {
+		t.Fatalf("A* visited %d, more than the %d of Dijkstra", a.Visited, d.Visited)
+	}
+}
@@ -0,0 +1,24 @@
+package tpl
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2019/aoc"
+	"github.com/xorkevin/advent2019/parse"
+)
+
+func Part1(r io.Reader) (aoc.Answer, error) {
+	nums, err := parse.Ints(r)
+	if err != nil {
+		return nil, err
+	}
+	k := 0
+	for _, num := range nums {
+		k += num
+	}
+	return aoc.Int(k), nil
+}
+
+func Part2(r io.Reader) (aoc.Answer, error) {
+	return Part1(r)
+}